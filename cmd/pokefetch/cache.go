@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func commandCache(_ context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("missing cache action, like \"cache stats|clear|keys\"")
+	}
+	inspector, ok := cfg.client.CacheInspector()
+	if !ok {
+		return errors.New("the cache in use can't be inspected")
+	}
+
+	switch *param {
+	case "stats":
+		stats := inspector.Stats()
+		fmt.Println("Cache stats:")
+		fmt.Printf("  - hit ratio: %.1f%%, stale hits included\n", stats.HitRatio()*100)
+		fmt.Println("  - hits:", stats.Hits)
+		fmt.Println("  - stale hits:", stats.StaleHits)
+		fmt.Println("  - misses:", stats.Misses-min(stats.StaleHits, stats.Misses))
+		fmt.Println("  - evictions:", stats.Evictions)
+		fmt.Println("  - expirations:", stats.Expirations)
+		fmt.Println("  - entries:", stats.Entries)
+		fmt.Println("  - bytes stored:", formatBytes(stats.Bytes))
+		fmt.Println("  - oldest entry age:", stats.OldestEntryAge.Round(time.Second))
+		rateLimitStats := cfg.client.RateLimitStats()
+		fmt.Println("Rate limiting:")
+		fmt.Println("  - throttled requests:", rateLimitStats.Throttled)
+		fmt.Println("  - total wait:", rateLimitStats.TotalWait.Round(time.Millisecond))
+	case "clear":
+		inspector.Clear()
+		fmt.Println("Cache cleared")
+	case "keys":
+		keys := inspector.Keys()
+		if len(keys) == 0 {
+			return fmt.Errorf("the cache is empty")
+		}
+		fmt.Println("Cached keys:")
+		for _, key := range keys {
+			fmt.Println("  -", key)
+		}
+	default:
+		return fmt.Errorf("unknown cache action %q, like \"cache stats|clear|keys\"", *param)
+	}
+	return nil
+}
+
+func formatBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := unit, 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
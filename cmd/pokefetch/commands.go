@@ -1,17 +1,22 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 
-	rand "math/rand/v2"
+	"github.com/maniac-en/pokefetch/internal/catch"
+	"github.com/maniac-en/pokefetch/internal/client"
 )
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*config, *string) error
+	callback    func(context.Context, *config, *string) error
 }
 
 func getCommands() map[string]cliCommand {
@@ -43,7 +48,7 @@ func getCommands() map[string]cliCommand {
 		},
 		"catch": {
 			name:        "catch",
-			description: "Catch a pookemon",
+			description: "Catch a pokemon, like \"catch <pokemon> [poke|great|ultra|master]\"",
 			callback:    commandCatch,
 		},
 		"inspect": {
@@ -51,21 +56,116 @@ func getCommands() map[string]cliCommand {
 			description: "Inspect a caught pokemon",
 			callback:    commandInspect,
 		},
+		"encounter": {
+			name:        "encounter",
+			description: "Look for a wild pokemon in the explored area, like \"encounter [method]\"",
+			callback:    commandEncounter,
+		},
+		"walk": {
+			name:        "walk",
+			description: "Walk around the explored area's grass looking for a wild pokemon",
+			callback:    commandWalk,
+		},
+		"version": {
+			name:        "version",
+			description: "Only show the data of one game, like \"version red\", or \"version all\"",
+			callback:    commandVersion,
+		},
+		"moves": {
+			name:        "moves",
+			description: "List out the moves a pokemon learns, like \"moves <pokemon>\"",
+			callback:    commandMoves,
+		},
 		"pokedex": {
 			name:        "pokedex",
 			description: "List out all the caught pokemons",
 			callback:    commandPokedex,
 		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "Show how a pokemon evolves, like \"evolutions <pokemon>\"",
+			callback:    commandEvolutions,
+		},
+		"types": {
+			name:        "types",
+			description: "Show the weaknesses and resistances of a pokemon, like \"types <pokemon>\"",
+			callback:    commandTypes,
+		},
+		"typechart": {
+			name:        "typechart",
+			description: "Show the type effectiveness chart",
+			callback:    commandTypechart,
+		},
+		"move": {
+			name:        "move",
+			description: "Look up a move, like \"move <move-name>\"",
+			callback:    commandMove,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Look up an ability, like \"ability <ability-name>\"",
+			callback:    commandAbility,
+		},
+		"list": {
+			name:        "list",
+			description: "List any PokeAPI resource, like \"list <resource> [count|all]\"",
+			callback:    commandList,
+		},
+		"items": {
+			name:        "items",
+			description: "List out the items in your bag",
+			callback:    commandItems,
+		},
+		"item": {
+			name:        "item",
+			description: "Look up an item, like \"item <item-name>\"",
+			callback:    commandItem,
+		},
+		"use": {
+			name:        "use",
+			description: "Use an item on a caught pokemon, like \"use <item> <pokemon>\"",
+			callback:    commandUse,
+		},
+		"save": {
+			name:        "save",
+			description: "Save the session to a slot, like \"save <slot>\"",
+			callback:    commandSave,
+		},
+		"load": {
+			name:        "load",
+			description: "Load the session from a slot, like \"load <slot>\"",
+			callback:    commandLoad,
+		},
+		"slots": {
+			name:        "slots",
+			description: "List out all the save slots",
+			callback:    commandSlots,
+		},
+		"delete-slot": {
+			name:        "delete-slot",
+			description: "Delete a save slot, like \"delete-slot <slot>\"",
+			callback:    commandDeleteSlot,
+		},
+		"cache": {
+			name:        "cache",
+			description: "Inspect or manage the cache, like \"cache stats|clear|keys\"",
+			callback:    commandCache,
+		},
 	}
 }
 
-func commandExit(cfg *config, _ *string) error {
+func commandExit(_ context.Context, cfg *config, _ *string) error {
+	if err := cfg.autosave(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error executing command:", err)
+	}
 	fmt.Println("Closing the PokeFetch... Goodbye!")
+	cfg.transcript.close()
+	cfg.client.Close()
 	os.Exit(0)
 	return nil
 }
 
-func commandHelp(cfg *config, _ *string) error {
+func commandHelp(_ context.Context, cfg *config, _ *string) error {
 	fmt.Println("\nWelcome to the PokeFetch!")
 	fmt.Println("Usage:")
 	fmt.Println()
@@ -75,8 +175,8 @@ func commandHelp(cfg *config, _ *string) error {
 	return nil
 }
 
-func commandMapf(cfg *config, _ *string) error {
-	pokeMapAreas, err := cfg.client.GetMapAreas(cfg.nextMapAreaURL)
+func commandMapf(ctx context.Context, cfg *config, _ *string) error {
+	pokeMapAreas, err := cfg.client.GetMapAreasContext(ctx, cfg.nextMapAreaURL)
 	if err != nil {
 		return err
 	}
@@ -90,12 +190,12 @@ func commandMapf(cfg *config, _ *string) error {
 	return nil
 }
 
-func commandMapb(cfg *config, _ *string) error {
+func commandMapb(ctx context.Context, cfg *config, _ *string) error {
 	if cfg.prevMapAreaURL == nil {
 		return errors.New("you're on the first page")
 	}
 
-	pokeMapAreas, err := cfg.client.GetMapAreas(cfg.prevMapAreaURL)
+	pokeMapAreas, err := cfg.client.GetMapAreasContext(ctx, cfg.prevMapAreaURL)
 	if err != nil {
 		return err
 	}
@@ -109,77 +209,186 @@ func commandMapb(cfg *config, _ *string) error {
 	return nil
 }
 
-func commandExplore(cfg *config, param *string) error {
+func commandExplore(ctx context.Context, cfg *config, param *string) error {
 	if param == nil {
 		return fmt.Errorf("can't explore empty map name, please provide a valid map name")
 	}
-	pokeMapArea, err := cfg.client.GetMapArea(param)
+	pokeMapArea, err := cfg.client.GetMapAreaContext(ctx, param)
 	if err != nil {
 		return err
 	}
+	cfg.exploredArea = &pokeMapArea
 
 	fmt.Println("\nFound Pokemon:")
 	for _, pokemonEncounter := range pokeMapArea.PokemonEncounters {
+		// only the pokemons living there in the game shown
+		found := false
+		for _, versionDetails := range pokemonEncounter.VersionDetails {
+			found = found || cfg.inVersion(versionDetails.Version.Name)
+		}
+		if !found {
+			continue
+		}
 		fmt.Println("-", pokemonEncounter.Pokemon.Name)
 	}
+	fmt.Println("Look for a wild one with the encounter command")
 
 	return nil
 }
 
-func commandCatch(cfg *config, param *string) error {
-	if param == nil {
-		return fmt.Errorf("can't catch a pokemon with no name, please provide one")
+func commandCatch(ctx context.Context, cfg *config, param *string) error {
+	var args []string
+	if param != nil {
+		args = strings.Fields(*param)
+	}
+	if len(args) > 2 || (len(args) == 0 && cfg.wild == nil) {
+		return fmt.Errorf("can't catch a pokemon with no name, please provide one, like \"catch <pokemon> [ball]\"")
+	}
+	// the pokemon's name can be left out to catch the wild one met last
+	if cfg.wild != nil && (len(args) == 0 || (len(args) == 1 && isBall(args[0]))) {
+		args = append([]string{cfg.wild.pokemon.Name}, args...)
 	}
-	if pokemon, ok := cfg.pokedex[*param]; ok {
+	ball := catch.PokeBall
+	if len(args) == 2 {
+		var err error
+		if ball, err = catch.ParseBall(args[1]); err != nil {
+			return err
+		}
+	}
+	if pokemon, ok := cfg.pokedex[args[0]]; ok {
 		fmt.Println("You already caught", pokemon.Name)
 		return nil
 	}
-	pokemon, err := cfg.client.GetPokemon(param)
-	if err != nil {
+	if cfg.inventory.Count(string(ball)) == 0 {
+		return fmt.Errorf("you have no %s left", ball)
+	}
+	var pokemon client.Pokemon
+	var target catch.Target
+	if cfg.wild != nil && cfg.wild.pokemon.Name == args[0] {
+		pokemon, target = cfg.wild.pokemon, cfg.wild.target()
+	} else {
+		var err error
+		if pokemon, err = cfg.client.GetPokemonContext(ctx, &args[0]); err != nil {
+			return err
+		}
+		species, err := cfg.client.GetPokemonSpeciesContext(ctx, speciesNameOf(pokemon))
+		if err != nil {
+			return err
+		}
+		target = catchTarget(pokemon, species)
+	}
+	if err := cfg.inventory.Take(string(ball)); err != nil {
 		return err
 	}
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemon.Name)
-	chance := float64(rand.IntN(pokemon.BaseExperience))
-	// fail if chance less than 40%
-	if (chance / float64(pokemon.BaseExperience)) < 0.4 {
+
+	fmt.Printf("Throwing a %s at %s...\n", ball, pokemon.Name)
+	result := catch.Throw(cfg.rng, target, ball)
+	for range result.Shakes {
+		fmt.Println("The ball shook...")
+	}
+	if !result.Caught {
 		fmt.Println(pokemon.Name, "escaped!")
-	} else {
-		fmt.Println(pokemon.Name, "was caught!")
-		fmt.Println("You may now inspect it with the inspect command")
-		cfg.pokedex[pokemon.Name] = pokemon
+		return cfg.autosave()
+	}
+	fmt.Println(pokemon.Name, "was caught!")
+	fmt.Println("You may now inspect it with the inspect command")
+	cfg.pokedex[pokemon.Name] = pokemon
+	if cfg.wild != nil && cfg.wild.pokemon.Name == pokemon.Name {
+		cfg.wild = nil
+	}
+	cfg.collectHeldItems(pokemon)
+	return cfg.autosave()
+}
+
+func isBall(name string) bool {
+	_, err := catch.ParseBall(name)
+	return err == nil
+}
+
+// catchTarget is the pokemon as a healthy wild one
+func catchTarget(pokemon client.Pokemon, species client.PokemonSpecies) catch.Target {
+	hp := 1
+	for _, stat := range pokemon.Stats {
+		if stat.Stat.Name == "hp" {
+			hp = stat.BaseStat
+		}
+	}
+	return catch.Target{
+		CaptureRate: species.CaptureRate,
+		MaxHP:       hp,
+		HP:          hp,
 	}
-	return nil
 }
 
-func commandInspect(cfg *config, param *string) error {
+func commandInspect(ctx context.Context, cfg *config, param *string) error {
 	if param == nil {
 		return fmt.Errorf("can't inspect a pokemon with no name, please provide one")
 	}
-	if pokemon, ok := cfg.pokedex[*param]; ok {
-		fmt.Println("Name:", pokemon.Name)
-		fmt.Println("Height:", pokemon.Height)
-		fmt.Println("Weight:", pokemon.Weight)
-		fmt.Println("Stats:")
-		for _, stat := range pokemon.Stats {
-			fmt.Printf("  - %s: %d\n", stat.Stat.Name, stat.BaseStat)
-		}
-		fmt.Println("Types:")
-		for _, t := range pokemon.Types {
-			fmt.Printf("  - %s\n", t.Type.Name)
-		}
-		return nil
-	} else {
+	pokemon, ok := cfg.pokedex[*param]
+	if !ok {
 		return fmt.Errorf("you have not caught that pokemon")
 	}
+	fmt.Println("Name:", pokemon.Name)
+	fmt.Println("Height:", pokemon.Height)
+	fmt.Println("Weight:", pokemon.Weight)
+	fmt.Println("Stats:")
+	for _, stat := range pokemon.Stats {
+		fmt.Printf("  - %s: %d\n", stat.Stat.Name, stat.BaseStat)
+	}
+	fmt.Println("Types:")
+	for _, t := range pokemon.Types {
+		fmt.Printf("  - %s\n", t.Type.Name)
+	}
+
+	species, err := cfg.client.GetPokemonSpeciesContext(ctx, speciesNameOf(pokemon))
+	if err != nil {
+		return fmt.Errorf("failed to fetch the species details: %w", err)
+	}
+	printSpecies(species)
+	return nil
+}
+
+func printSpecies(species client.PokemonSpecies) {
+	fmt.Println("Species:")
+	if genus, ok := species.Genus(client.DefaultLanguage); ok {
+		fmt.Println("  Genus:", genus)
+	}
+	fmt.Println("  Generation:", species.Generation.Name)
+	fmt.Println("  Color:", species.Color.Name)
+	if species.Habitat != nil {
+		fmt.Println("  Habitat:", species.Habitat.Name)
+	}
+	fmt.Println("  Capture rate:", species.CaptureRate)
+	fmt.Println("  Base happiness:", species.BaseHappiness)
+	switch {
+	case species.IsLegendary:
+		fmt.Println("  Legendary pokemon")
+	case species.IsMythical:
+		fmt.Println("  Mythical pokemon")
+	}
+	if flavorText, ok := species.FlavorText(client.DefaultLanguage); ok {
+		fmt.Println("  Pokedex entry:", flavorText)
+	}
+}
+
+// speciesNameOf returns the species of the pokemon, forms like deoxys-attack
+// belong to a species named differently
+func speciesNameOf(pokemon client.Pokemon) *string {
+	speciesName := pokemon.Species.Name
+	if speciesName == "" {
+		speciesName = pokemon.Name
+	}
+	return &speciesName
 }
 
-func commandPokedex(cfg *config, _ *string) error {
+func commandPokedex(_ context.Context, cfg *config, _ *string) error {
 	if len(cfg.pokedex) == 0 {
 		return fmt.Errorf("your pokedex is empty, go catch some pokemons with catch command")
 	}
 	fmt.Println("Your Pokedex:")
-	for k := range cfg.pokedex {
-		fmt.Println("  -", k)
+	// sorted, so a replayed session prints the same
+	for _, name := range slices.Sorted(maps.Keys(cfg.pokedex)) {
+		fmt.Println("  -", name)
 	}
 	return nil
 }
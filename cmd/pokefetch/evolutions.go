@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+func commandEvolutions(ctx context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't look up the evolutions of a pokemon with no name, please provide one")
+	}
+	pokemon, err := cfg.client.GetPokemonContext(ctx, param)
+	if err != nil {
+		return err
+	}
+	species, err := cfg.client.GetPokemonSpeciesContext(ctx, speciesNameOf(pokemon))
+	if err != nil {
+		return err
+	}
+	chainID, ok := species.EvolutionChain.ID()
+	if !ok {
+		return fmt.Errorf("%s has no evolution chain", species.Name)
+	}
+	chain, err := cfg.client.GetEvolutionChainContext(ctx, &chainID)
+	if err != nil {
+		return err
+	}
+	if len(chain.Chain.EvolvesTo) == 0 {
+		fmt.Printf("%s does not evolve\n", species.Name)
+		return nil
+	}
+	printChainLink(chain.Chain, species.Name, "", "")
+	return nil
+}
+
+// printChainLink prints the link and the species it evolves to as an indented
+// tree, marking the species being looked up
+func printChainLink(link client.ChainLink, current, prefix, childPrefix string) {
+	line := link.Species.Name
+	if link.IsBaby {
+		line += " (baby)"
+	}
+	if len(link.EvolutionDetails) > 0 {
+		ways := make([]string, 0, len(link.EvolutionDetails))
+		for _, detail := range link.EvolutionDetails {
+			ways = append(ways, detail.String())
+		}
+		line += " <- " + strings.Join(ways, " or ")
+	}
+	if link.Species.Name == current {
+		line += "  *"
+	}
+	fmt.Println(prefix + line)
+	for i, next := range link.EvolvesTo {
+		if i == len(link.EvolvesTo)-1 {
+			printChainLink(next, current, childPrefix+"`-- ", childPrefix+"    ")
+		} else {
+			printChainLink(next, current, childPrefix+"|-- ", childPrefix+"|   ")
+		}
+	}
+}
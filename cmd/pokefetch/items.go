@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+const (
+	// evolutionCategory is the item category of the evolution stones & co
+	evolutionCategory string = "evolution"
+	berrySuffix       string = "-berry"
+)
+
+func commandItems(_ context.Context, cfg *config, _ *string) error {
+	if len(cfg.inventory) == 0 {
+		return fmt.Errorf("your bag is empty")
+	}
+	fmt.Println("Your bag:")
+	for _, item := range cfg.inventory.Items() {
+		fmt.Printf("  - %s x%d\n", item, cfg.inventory.Count(item))
+	}
+	return nil
+}
+
+func commandItem(ctx context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't look up an item with no name, please provide one")
+	}
+	itemName := resourceName(*param)
+	item, err := cfg.client.GetItemContext(ctx, &itemName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Name:", item.Name)
+	fmt.Println("Category:", item.Category.Name)
+	fmt.Println("Cost:", item.Cost)
+	if effect, ok := item.Effect(client.DefaultLanguage); ok {
+		fmt.Println("Effect:", effect)
+	}
+	fmt.Println("In your bag:", cfg.inventory.Count(item.Name))
+
+	berryName, ok := strings.CutSuffix(item.Name, berrySuffix)
+	if !ok {
+		return nil
+	}
+	berry, err := cfg.client.GetBerryContext(ctx, &berryName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the berry details: %w", err)
+	}
+	fmt.Println("Berry:")
+	fmt.Println("  Firmness:", berry.Firmness.Name)
+	fmt.Println("  Growth time:", berry.GrowthTime, "hours per stage")
+	fmt.Printf("  Natural gift: %s, power %d\n", berry.NaturalGiftType.Name, berry.NaturalGiftPower)
+	for _, flavor := range berry.Flavors {
+		if flavor.Potency > 0 {
+			fmt.Printf("  - %s: %d\n", flavor.Flavor.Name, flavor.Potency)
+		}
+	}
+	return nil
+}
+
+func commandUse(ctx context.Context, cfg *config, param *string) error {
+	var args []string
+	if param != nil {
+		args = strings.Fields(*param)
+	}
+	if len(args) != 2 {
+		return fmt.Errorf("please tell which item to use on which pokemon, like \"use <item> <pokemon>\"")
+	}
+	itemName, pokemonName := args[0], args[1]
+	if cfg.inventory.Count(itemName) == 0 {
+		return fmt.Errorf("you have no %s in your bag", itemName)
+	}
+	pokemon, ok := cfg.pokedex[pokemonName]
+	if !ok {
+		return fmt.Errorf("you have not caught that pokemon")
+	}
+	item, err := cfg.client.GetItemContext(ctx, &itemName)
+	if err != nil {
+		return err
+	}
+	if item.Category.Name != evolutionCategory {
+		fmt.Println("It won't have any effect.")
+		return nil
+	}
+
+	evolved, ok, err := cfg.evolveWithItem(ctx, pokemon, item.Name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("It won't have any effect.")
+		return nil
+	}
+	if err := cfg.inventory.Take(item.Name); err != nil {
+		return err
+	}
+	delete(cfg.pokedex, pokemon.Name)
+	cfg.pokedex[evolved.Name] = evolved
+	fmt.Printf("What? %s is evolving!\n", pokemon.Name)
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", pokemon.Name, evolved.Name)
+	return cfg.autosave()
+}
+
+// evolveWithItem looks up what the pokemon evolves into when the item is used
+// on it, reporting false when it doesn't react to the item
+func (cfg *config) evolveWithItem(ctx context.Context, pokemon client.Pokemon, itemName string) (client.Pokemon, bool, error) {
+	species, err := cfg.client.GetPokemonSpeciesContext(ctx, speciesNameOf(pokemon))
+	if err != nil {
+		return client.Pokemon{}, false, err
+	}
+	chainID, ok := species.EvolutionChain.ID()
+	if !ok {
+		return client.Pokemon{}, false, nil
+	}
+	chain, err := cfg.client.GetEvolutionChainContext(ctx, &chainID)
+	if err != nil {
+		return client.Pokemon{}, false, err
+	}
+	link, ok := chain.Chain.Find(species.Name)
+	if !ok {
+		return client.Pokemon{}, false, nil
+	}
+	for _, next := range link.EvolvesTo {
+		for _, detail := range next.EvolutionDetails {
+			if detail.Trigger.Name != "use-item" || detail.Item == nil || detail.Item.Name != itemName {
+				continue
+			}
+			evolvedName := next.Species.Name
+			evolved, err := cfg.client.GetPokemonContext(ctx, &evolvedName)
+			if err != nil {
+				return client.Pokemon{}, false, err
+			}
+			return evolved, true, nil
+		}
+	}
+	return client.Pokemon{}, false, nil
+}
+
+// collectHeldItems rolls for every item the caught pokemon may have been
+// holding, each is found as often as it's held in the wild in the games shown
+func (cfg *config) collectHeldItems(pokemon client.Pokemon) {
+	for _, held := range pokemon.HeldItems {
+		rarity := 0
+		for _, details := range held.VersionDetails {
+			if cfg.inVersion(details.Version.Name) {
+				rarity = max(rarity, details.Rarity)
+			}
+		}
+		if cfg.rng.IntN(100) < rarity {
+			cfg.inventory.Add(held.Item.Name, 1)
+			fmt.Printf("%s was holding a %s, it's now in your bag!\n", pokemon.Name, held.Item.Name)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultListCount is how many resources list prints when not told otherwise
+const defaultListCount int = 20
+
+func commandList(ctx context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't list nothing, please provide a resource like pokemon, move, item or type")
+	}
+	args := strings.Fields(*param)
+	resource := args[0]
+	count := defaultListCount
+	if len(args) > 1 {
+		if args[1] == "all" {
+			count = 0
+		} else {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid count %q, please provide a positive number or all", args[1])
+			}
+			count = n
+		}
+	}
+
+	listed := 0
+	for named, err := range cfg.client.List(ctx, resource) {
+		if err != nil {
+			return err
+		}
+		fmt.Println(named.Name)
+		listed++
+		if listed == count {
+			break
+		}
+	}
+	return nil
+}
@@ -1,20 +1,187 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/maniac-en/pokefetch/internal/cache"
 	"github.com/maniac-en/pokefetch/internal/client"
+	"github.com/maniac-en/pokefetch/internal/inventory"
+	"github.com/maniac-en/pokefetch/internal/savefile"
+	"github.com/maniac-en/pokefetch/internal/settings"
+	"github.com/maniac-en/pokefetch/internal/typechart"
+)
+
+const (
+	clientTimeout time.Duration = 5 * time.Second
+	cacheInterval time.Duration = 1 * time.Minute
+	// maxCacheBytes bounds the responses kept in memory, pokemon payloads are
+	// large and long sessions crawl a lot of them
+	maxCacheBytes int = 32 << 20
+	// requestsPerSecond and requestsBurst keep PokeFetch within the PokeAPI
+	// fair use policy
+	requestsPerSecond float64 = 10
+	requestsBurst     int     = 20
 )
 
 func main() {
-	pokeClient, err := client.NewClient(5*time.Second, 1*time.Minute)
+	if err := run(os.Args[1:], os.Stdin); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// run starts a PokeFetch session with the command line args, reading the
+// commands from input unless a transcript is replayed
+func run(args []string, input io.Reader) error {
+	flags := flag.NewFlagSet("pokefetch", flag.ExitOnError)
+	configPath := flags.String("config", "", "path to the config file (default <user config dir>/pokefetch/config.json)")
+	flagSettings := settings.Settings{}
+	flags.StringVar(&flagSettings.BaseURL, "base-url", "",
+		"PokeAPI base URL, e.g. http://localhost:8000/api (env "+settings.EnvBaseURL+")")
+	flags.StringVar(&flagSettings.APIVersion, "api-version", "",
+		"PokeAPI version, e.g. /v2 (env "+settings.EnvAPIVersion+")")
+	seed := flags.Uint64("seed", 0, "seed of the session's randomness (default random)")
+	transcriptPath := flags.String("transcript", "", "record the session's seed, starting state and commands to this file")
+	replayPath := flags.String("replay", "", "play again the session recorded in this transcript, without saving anything")
+	flags.Parse(args)
+	if *replayPath != "" && (*seed != 0 || *transcriptPath != "") {
+		return errors.New("-replay takes the seed and the commands from the transcript, it can't be combined with -seed or -transcript")
+	}
+
+	appSettings, err := loadSettings(*configPath, flagSettings)
 	if err != nil {
-		panic(fmt.Sprintf("error creating a client: %v", err))
+		return err
 	}
+	pokeClient, err := newClient(appSettings)
+	if err != nil {
+		return err
+	}
+	defer pokeClient.Close()
 	cfg := &config{
-		client:  *pokeClient,
-		pokedex: make(map[string]client.Pokemon),
+		client:    pokeClient,
+		pokedex:   make(map[string]client.Pokemon),
+		typeChart: typechart.New(),
+		inventory: inventory.Starter(),
+	}
+
+	if *replayPath != "" {
+		replay, err := openReplay(*replayPath)
+		if err != nil {
+			return err
+		}
+		defer replay.close()
+		// the replay starts where the recorded session did, and never touches
+		// the trainer's save file
+		cfg.replaying = true
+		cfg.seedRNG(replay.seed)
+		cfg.restore(replay.start)
+		input = replay.commands
+		fmt.Println("Session seed:", cfg.seed)
+	} else {
+		if *seed == 0 {
+			*seed = randomSeed()
+		}
+		cfg.seedRNG(*seed)
+		fmt.Println("Session seed:", cfg.seed)
+		loadSaveFile(cfg)
+		if *transcriptPath != "" {
+			cfg.transcript, err = openTranscript(*transcriptPath, cfg.seed, cfg.snapshot())
+			if err != nil {
+				return err
+			}
+			defer cfg.transcript.close()
+		}
+	}
+	ReplStart(cfg, input)
+	return nil
+}
+
+// loadSettings layers the config file, the environment and the flags, the
+// config file is optional unless its path is given explicitly
+func loadSettings(configPath string, flagSettings settings.Settings) (settings.Settings, error) {
+	path := configPath
+	if path == "" {
+		// without a user config dir there's no config file to read, the
+		// environment and the flags still apply
+		path, _ = settings.DefaultPath()
+	} else if _, err := os.Stat(path); err != nil {
+		return settings.Settings{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var fileSettings settings.Settings
+	if path != "" {
+		var err error
+		fileSettings, err = settings.LoadFile(path)
+		if err != nil {
+			return settings.Settings{}, err
+		}
+	}
+	return fileSettings.Override(settings.FromEnv(os.Getenv)).Override(flagSettings), nil
+}
+
+// newClient creates a client caching on disk when possible, falling back to an
+// in memory cache only
+func newClient(appSettings settings.Settings) (*client.Client, error) {
+	opts := []client.Option{
+		client.WithCacheOptions(cache.WithMaxBytes(maxCacheBytes)),
+		client.WithStaleWhileRevalidate(),
+		client.WithRateLimit(requestsPerSecond, requestsBurst),
+	}
+	if appSettings.BaseURL != "" {
+		opts = append(opts, client.WithBaseURL(appSettings.BaseURL))
+	}
+	if appSettings.APIVersion != "" {
+		opts = append(opts, client.WithAPIVersion(appSettings.APIVersion))
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err == nil {
+		diskOpt := client.WithCacheDir(filepath.Join(cacheDir, "pokefetch"))
+		var pokeClient *client.Client
+		pokeClient, err = client.NewClient(clientTimeout, cacheInterval, append(opts, diskOpt)...)
+		if err == nil || errors.Is(err, client.ErrInvalidBaseURL) {
+			return pokeClient, err
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Warning: responses won't be cached on disk:", err)
+	return client.NewClient(clientTimeout, cacheInterval, opts...)
+}
+
+// loadSaveFile restores the session from the previous run, any failure here
+// is reported but never stops the REPL from starting
+func loadSaveFile(cfg *config) {
+	if slots, err := savefile.DefaultSlots(); err == nil {
+		cfg.slots = &slots
+	}
+
+	savePath, err := savefile.DefaultPath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: progress won't be saved:", err)
+		return
+	}
+	cfg.savePath = savePath
+
+	saveFile, err := savefile.Load(savePath)
+	switch {
+	case err == nil:
+		cfg.restore(saveFile)
+	case errors.Is(err, fs.ErrNotExist):
+		// first run, nothing to restore
+	case errors.Is(err, savefile.ErrCorrupt), errors.Is(err, savefile.ErrUnsupportedVersion):
+		backupPath, qErr := savefile.Quarantine(savePath)
+		if qErr != nil {
+			fmt.Fprintln(os.Stderr, "Warning: progress won't be saved:", qErr)
+			cfg.savePath = ""
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\nMoved it to %s, starting with an empty pokedex\n", err, backupPath)
+	default:
+		fmt.Fprintln(os.Stderr, "Warning: progress won't be saved:", err)
+		cfg.savePath = ""
 	}
-	ReplStart(cfg)
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+func commandMove(ctx context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't look up a move with no name, please provide one")
+	}
+	moveName := resourceName(*param)
+	move, err := cfg.client.GetMoveContext(ctx, &moveName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Name:", move.Name)
+	fmt.Println("Type:", move.Type.Name)
+	fmt.Println("Damage class:", move.DamageClass.Name)
+	fmt.Println("Power:", optionalStat(move.Power))
+	fmt.Println("Accuracy:", optionalStat(move.Accuracy))
+	fmt.Println("PP:", optionalStat(move.PP))
+	if move.Priority != 0 {
+		fmt.Println("Priority:", move.Priority)
+	}
+	if effect, ok := move.Effect(client.DefaultLanguage); ok {
+		fmt.Println("Effect:", effect)
+	}
+	fmt.Println("Learned by", len(move.LearnedByPokemon), "pokemons")
+	return nil
+}
+
+func commandAbility(ctx context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't look up an ability with no name, please provide one")
+	}
+	abilityName := resourceName(*param)
+	ability, err := cfg.client.GetAbilityContext(ctx, &abilityName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Name:", ability.Name)
+	fmt.Println("Generation:", ability.Generation.Name)
+	if effect, ok := ability.Effect(client.DefaultLanguage); ok {
+		fmt.Println("Effect:", effect)
+	}
+	fmt.Println("Pokemons:")
+	for _, pokemon := range ability.Pokemon {
+		if pokemon.IsHidden {
+			fmt.Printf("  - %s (hidden)\n", pokemon.Pokemon.Name)
+		} else {
+			fmt.Printf("  - %s\n", pokemon.Pokemon.Name)
+		}
+	}
+	return nil
+}
+
+// learnedMove is a move along the ways a pokemon learns it
+type learnedMove struct {
+	name string
+	// level is the lowest level it's learned at by leveling up, 0 if never
+	level int
+	ways  []string
+}
+
+func commandMoves(ctx context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't list the moves of a pokemon with no name, please provide one")
+	}
+	pokemon, err := cfg.client.GetPokemonContext(ctx, param)
+	if err != nil {
+		return err
+	}
+
+	moves := learnedMoves(pokemon, cfg.versionGroupName())
+	if len(moves) == 0 {
+		if cfg.version != nil {
+			return fmt.Errorf("%s learns no move in pokemon %s", pokemon.Name, cfg.version.Name)
+		}
+		return fmt.Errorf("%s learns no move", pokemon.Name)
+	}
+	fmt.Printf("Moves of %s:\n", pokemon.Name)
+	for _, move := range moves {
+		fmt.Printf("  - %s: %s\n", move.name, strings.Join(move.ways, ", "))
+	}
+	return nil
+}
+
+// learnedMoves lists the moves the pokemon learns in the version group, or in
+// any of them when it's "", the ones learned by leveling up first in the order
+// they're learned
+func learnedMoves(pokemon client.Pokemon, versionGroup string) []learnedMove {
+	var moves []learnedMove
+	for _, move := range pokemon.Moves {
+		learned := learnedMove{name: move.Move.Name}
+		for _, details := range move.VersionGroupDetails {
+			if versionGroup != "" && details.VersionGroup.Name != versionGroup {
+				continue
+			}
+			way := details.MoveLearnMethod.Name
+			if way == "level-up" {
+				// a level 0 counts as level 1, the lowest there is
+				level := max(details.LevelLearnedAt, 1)
+				if learned.level == 0 || level < learned.level {
+					learned.level = level
+				}
+				way = fmt.Sprintf("level %d", details.LevelLearnedAt)
+			}
+			if !slices.Contains(learned.ways, way) {
+				learned.ways = append(learned.ways, way)
+			}
+		}
+		if len(learned.ways) > 0 {
+			moves = append(moves, learned)
+		}
+	}
+
+	slices.SortFunc(moves, func(a, b learnedMove) int {
+		if (a.level == 0) != (b.level == 0) {
+			return cmp.Compare(b.level, a.level)
+		}
+		return cmp.Or(cmp.Compare(a.level, b.level), strings.Compare(a.name, b.name))
+	})
+	return moves
+}
+
+// resourceName turns a name typed with spaces into the PokeAPI one, e.g.
+// "thunder punch" into "thunder-punch"
+func resourceName(name string) string {
+	return strings.Join(strings.Fields(name), "-")
+}
+
+// optionalStat prints the stats some moves don't have as "-"
+func optionalStat(stat *int) string {
+	if stat == nil {
+		return "-"
+	}
+	return fmt.Sprint(*stat)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+func TestLearnedMoves(t *testing.T) {
+	var pokemon client.Pokemon
+	data := `{
+		"name": "bulbasaur",
+		"moves": [
+			{
+				"move": {"name": "swords-dance", "url": ""},
+				"version_group_details": [
+					{"level_learned_at": 0, "move_learn_method": {"name": "machine", "url": ""}, "version_group": {"name": "red-blue", "url": ""}}
+				]
+			},
+			{
+				"move": {"name": "vine-whip", "url": ""},
+				"version_group_details": [
+					{"level_learned_at": 13, "move_learn_method": {"name": "level-up", "url": ""}, "version_group": {"name": "red-blue", "url": ""}},
+					{"level_learned_at": 9, "move_learn_method": {"name": "level-up", "url": ""}, "version_group": {"name": "gold-silver", "url": ""}}
+				]
+			},
+			{
+				"move": {"name": "tackle", "url": ""},
+				"version_group_details": [
+					{"level_learned_at": 0, "move_learn_method": {"name": "level-up", "url": ""}, "version_group": {"name": "red-blue", "url": ""}}
+				]
+			},
+			{
+				"move": {"name": "growl", "url": ""},
+				"version_group_details": [
+					{"level_learned_at": 1, "move_learn_method": {"name": "level-up", "url": ""}, "version_group": {"name": "red-blue", "url": ""}}
+				]
+			},
+			{
+				"move": {"name": "giga-drain", "url": ""},
+				"version_group_details": [
+					{"level_learned_at": 0, "move_learn_method": {"name": "tutor", "url": ""}, "version_group": {"name": "gold-silver", "url": ""}}
+				]
+			}
+		]
+	}`
+	if err := json.Unmarshal([]byte(data), &pokemon); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		versionGroup string
+		expected     []learnedMove
+	}{
+		{
+			name:         "every version group, at the lowest level across them",
+			versionGroup: "",
+			expected: []learnedMove{
+				{name: "growl", level: 1, ways: []string{"level 1"}},
+				{name: "tackle", level: 1, ways: []string{"level 0"}},
+				{name: "vine-whip", level: 9, ways: []string{"level 13", "level 9"}},
+				{name: "giga-drain", ways: []string{"tutor"}},
+				{name: "swords-dance", ways: []string{"machine"}},
+			},
+		},
+		{
+			name:         "filtered to a version group",
+			versionGroup: "red-blue",
+			expected: []learnedMove{
+				{name: "growl", level: 1, ways: []string{"level 1"}},
+				{name: "tackle", level: 1, ways: []string{"level 0"}},
+				{name: "vine-whip", level: 13, ways: []string{"level 13"}},
+				{name: "swords-dance", ways: []string{"machine"}},
+			},
+		},
+		{
+			name:         "version group the pokemon learns nothing in",
+			versionGroup: "sword-shield",
+			expected:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := learnedMoves(pokemon, tt.versionGroup); !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -2,27 +2,102 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
+
+	rand "math/rand/v2"
 
 	"github.com/maniac-en/pokefetch/internal/client"
+	"github.com/maniac-en/pokefetch/internal/inventory"
+	"github.com/maniac-en/pokefetch/internal/savefile"
+	"github.com/maniac-en/pokefetch/internal/typechart"
 	"github.com/maniac-en/pokefetch/internal/utils"
 )
 
 type config struct {
-	client         client.Client
+	client         *client.Client
 	nextMapAreaURL *string
 	prevMapAreaURL *string
 	pokedex        map[string]client.Pokemon
+	savePath       string
+	slots          *savefile.Slots
+	// typeChart is filled with the types fetched so far
+	typeChart *typechart.Chart
+	inventory inventory.Inventory
+	// rng is the source of all the game's randomness, seeded with seed
+	rng        *rand.Rand
+	seed       uint64
+	transcript *transcript
+	// replaying sessions play a transcript again, they never write to disk
+	replaying bool
+	// exploredArea is where encounters happen, wild is the pokemon met last
+	exploredArea *client.PokeMapArea
+	wild         *wildPokemon
+	// version filters the game data to one game version, nil for all of them
+	version *client.Version
 }
 
 const (
 	PROMPT string = "PokeFetch > "
 )
 
-func ReplStart(cfg *config) {
-	scanner := bufio.NewScanner(os.Stdin)
+// interrupter turns Ctrl-C into cancelling the running command, instead of
+// killing the whole REPL
+type interrupter struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// listen handles the interrupts until signals is closed
+func (i *interrupter) listen(signals <-chan os.Signal) {
+	for range signals {
+		i.mu.Lock()
+		if i.cancel != nil {
+			i.cancel()
+			i.cancel = nil
+		} else {
+			fmt.Print("\n(use exit or Ctrl-D to quit)\n" + PROMPT)
+		}
+		i.mu.Unlock()
+	}
+}
+
+// start returns the context of a new command, cancelled on Ctrl-C
+func (i *interrupter) start() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.mu.Lock()
+	i.cancel = cancel
+	i.mu.Unlock()
+	return ctx
+}
+
+// stop marks the running command as done
+func (i *interrupter) stop() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.cancel != nil {
+		i.cancel()
+		i.cancel = nil
+	}
+}
+
+func ReplStart(cfg *config, input io.Reader) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer func() {
+		signal.Stop(signals)
+		close(signals)
+	}()
+	var interrupts interrupter
+	go interrupts.listen(signals)
+
+	scanner := bufio.NewScanner(input)
 	for {
 		fmt.Print(PROMPT)
 		if !scanner.Scan() {
@@ -30,6 +105,9 @@ func ReplStart(cfg *config) {
 		}
 
 		inputLine := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(inputLine), "#") {
+			continue
+		}
 		cleanedInputLine := utils.CleanInput(inputLine)
 		if len(cleanedInputLine) == 0 {
 			continue
@@ -43,9 +121,14 @@ func ReplStart(cfg *config) {
 		if handler, ok := getCommands()[inputCmd]; !ok {
 			fmt.Println("Unknown command:", inputCmd)
 		} else {
-			err := handler.callback(cfg, params)
-			if err != nil {
-				fmt.Fprintln(os.Stderr, "Error executing command:", err)
+			cfg.transcript.record(inputLine)
+			ctx := interrupts.start()
+			err := handler.callback(ctx, cfg, params)
+			interrupts.stop()
+			if errors.Is(err, context.Canceled) {
+				fmt.Println("\nCommand cancelled")
+			} else if err != nil {
+				fmt.Fprintln(os.Stderr, "Error executing command:", friendlyError(err))
 			}
 		}
 	}
@@ -53,3 +136,28 @@ func ReplStart(cfg *config) {
 		fmt.Fprintln(os.Stderr, "reading standard input: ", err)
 	}
 }
+
+// friendlyError explains the PokeAPI failures in the trainer's words, any other
+// error is shown as is
+func friendlyError(err error) string {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		return "couldn't find that, double-check the name and try again"
+	case errors.Is(err, client.ErrRateLimited):
+		return "PokeAPI is getting too many requests, wait a bit and try again"
+	case errors.Is(err, client.ErrServer):
+		return fmt.Sprintf("PokeAPI is having trouble right now (status %d), try again later", apiErr.StatusCode)
+	case errors.Is(err, client.ErrTimeout):
+		return "PokeAPI took too long to answer, check your connection and try again"
+	case errors.Is(err, client.ErrNetwork):
+		return "couldn't reach PokeAPI, check your connection and try again"
+	case errors.Is(err, client.ErrDecode):
+		return "PokeAPI sent back something PokeFetch doesn't understand"
+	default:
+		return err.Error()
+	}
+}
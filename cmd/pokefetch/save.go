@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maniac-en/pokefetch/internal/savefile"
+)
+
+// snapshot captures the parts of the session which are persisted
+func (cfg *config) snapshot() *savefile.SaveFile {
+	saveFile := savefile.New()
+	saveFile.Pokedex = cfg.pokedex
+	saveFile.NextMapAreaURL = cfg.nextMapAreaURL
+	saveFile.PrevMapAreaURL = cfg.prevMapAreaURL
+	saveFile.Inventory = cfg.inventory
+	saveFile.Seed = cfg.seed
+	return saveFile
+}
+
+// restore replaces the session state with the one from a save file, the
+// session keeps its own seed
+func (cfg *config) restore(saveFile *savefile.SaveFile) {
+	cfg.pokedex = saveFile.Pokedex
+	cfg.nextMapAreaURL = saveFile.NextMapAreaURL
+	cfg.prevMapAreaURL = saveFile.PrevMapAreaURL
+	cfg.inventory = saveFile.Inventory
+}
+
+// autosave writes the current session to the save file, it's a no-op when the
+// save file location couldn't be determined on startup
+func (cfg *config) autosave() error {
+	if cfg.savePath == "" {
+		return nil
+	}
+	if err := savefile.Save(cfg.savePath, cfg.snapshot()); err != nil {
+		return fmt.Errorf("failed to save progress: %w", err)
+	}
+	return nil
+}
+
+func (cfg *config) getSlots() (savefile.Slots, error) {
+	if cfg.transcript != nil || cfg.replaying {
+		// the slots' content isn't part of the transcript, so it wouldn't replay
+		return savefile.Slots{}, errors.New("save slots are unavailable while recording or replaying a transcript")
+	}
+	if cfg.slots == nil {
+		return savefile.Slots{}, errors.New("save slots are unavailable on this machine")
+	}
+	return *cfg.slots, nil
+}
+
+func commandSave(_ context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't save to a slot with no name, please provide one")
+	}
+	slots, err := cfg.getSlots()
+	if err != nil {
+		return err
+	}
+	if err := slots.Save(*param, cfg.snapshot()); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d pokemons to slot %s\n", len(cfg.pokedex), *param)
+	return nil
+}
+
+func commandLoad(_ context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't load a slot with no name, please provide one")
+	}
+	slots, err := cfg.getSlots()
+	if err != nil {
+		return err
+	}
+	saveFile, err := slots.Load(*param)
+	if err != nil {
+		return err
+	}
+	cfg.restore(saveFile)
+	fmt.Printf("Loaded slot %s with %d pokemons\n", *param, len(cfg.pokedex))
+	return cfg.autosave()
+}
+
+func commandSlots(_ context.Context, cfg *config, _ *string) error {
+	slots, err := cfg.getSlots()
+	if err != nil {
+		return err
+	}
+	infos, err := slots.List()
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return fmt.Errorf("there are no saved slots, create one with save command")
+	}
+	fmt.Println("Save slots:")
+	for _, info := range infos {
+		if info.SavedAt.IsZero() {
+			fmt.Printf("  - %s (unreadable)\n", info.Name)
+			continue
+		}
+		fmt.Printf("  - %s: %d pokemons, saved %s\n",
+			info.Name, info.PokemonCount, info.SavedAt.Format(time.DateTime))
+	}
+	return nil
+}
+
+func commandDeleteSlot(_ context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't delete a slot with no name, please provide one")
+	}
+	slots, err := cfg.getSlots()
+	if err != nil {
+		return err
+	}
+	if err := slots.Delete(*param); err != nil {
+		return err
+	}
+	fmt.Println("Deleted slot", *param)
+	return nil
+}
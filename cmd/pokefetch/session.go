@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	rand "math/rand/v2"
+
+	"github.com/maniac-en/pokefetch/internal/savefile"
+)
+
+const (
+	transcriptSeedPrefix string = "# seed: "
+	transcriptSavePrefix string = "# save: "
+)
+
+// seedRNG restarts the session's random numbers from seed, all the game logic
+// draws from cfg.rng so the same seed and commands replay the same session
+func (cfg *config) seedRNG(seed uint64) {
+	cfg.seed = seed
+	cfg.rng = rand.New(rand.NewPCG(seed, seed))
+}
+
+// randomSeed picks the seed of a session started without one, 0 is kept to
+// mean "no seed given"
+func randomSeed() uint64 {
+	for {
+		if seed := rand.Uint64(); seed != 0 {
+			return seed
+		}
+	}
+}
+
+// transcript records the commands of a session along with its seed and the
+// state it started from, the REPL skips the "#" comment lines of the header
+type transcript struct {
+	file *os.File
+}
+
+func openTranscript(path string, seed uint64, start *savefile.SaveFile) (*transcript, error) {
+	startData, err := json.Marshal(start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the starting save: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transcript: %w", err)
+	}
+	header := fmt.Sprintf("# PokeFetch transcript, replay it with: pokefetch -replay %s\n%s%d\n%s%s\n",
+		path, transcriptSeedPrefix, seed, transcriptSavePrefix, startData)
+	if _, err := file.WriteString(header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write transcript: %w", err)
+	}
+	return &transcript{file: file}, nil
+}
+
+// record appends a command line, it's a no-op without a transcript
+func (t *transcript) record(line string) {
+	if t == nil {
+		return
+	}
+	// a failed write only costs the rest of the transcript
+	_, _ = t.file.WriteString(strings.TrimSpace(line) + "\n")
+}
+
+func (t *transcript) close() {
+	if t == nil {
+		return
+	}
+	t.file.Close()
+}
+
+// replay is a transcript opened to play its session again, from the same seed
+// and starting state, commands reads the recorded commands
+type replay struct {
+	file     *os.File
+	seed     uint64
+	start    *savefile.SaveFile
+	commands io.Reader
+}
+
+func openReplay(path string) (*replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	// not a bufio.Scanner, the starting save is a single long line
+	reader := bufio.NewReader(file)
+	r := &replay{file: file, commands: reader}
+	for r.seed == 0 || r.start == nil {
+		line, err := reader.ReadString('\n')
+		if err != nil || !strings.HasPrefix(line, "#") {
+			file.Close()
+			return nil, fmt.Errorf("invalid transcript %s: its header lacks the seed or the starting save", path)
+		}
+		line = strings.TrimSpace(line)
+		if value, ok := strings.CutPrefix(line, transcriptSeedPrefix); ok {
+			if r.seed, err = strconv.ParseUint(value, 10, 64); err != nil {
+				file.Close()
+				return nil, fmt.Errorf("invalid transcript %s: bad seed: %w", path, err)
+			}
+		} else if value, ok := strings.CutPrefix(line, transcriptSavePrefix); ok {
+			if r.start, err = savefile.Decode([]byte(value)); err != nil {
+				file.Close()
+				return nil, fmt.Errorf("invalid transcript %s: bad starting save: %w", path, err)
+			}
+		}
+	}
+	return r, nil
+}
+
+func (r *replay) close() {
+	r.file.Close()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakePokeAPI serves the few resources the sessions below ask for
+func fakePokeAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	resources := map[string]string{
+		"/api/v2/pokemon/bulbasaur": `{
+			"id": 1, "name": "bulbasaur", "base_experience": 64,
+			"species": {"name": "bulbasaur", "url": ""},
+			"stats": [{"base_stat": 45, "stat": {"name": "hp", "url": ""}}]
+		}`,
+		"/api/v2/pokemon-species/bulbasaur": `{"id": 1, "name": "bulbasaur", "capture_rate": 45}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := resources[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runSession runs PokeFetch with args and input, returning everything it wrote
+// to stdout and stderr
+func runSession(t *testing.T, args []string, input string) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = writer, writer
+	output := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, reader)
+		output <- buf.String()
+	}()
+
+	runErr := run(args, strings.NewReader(input))
+	os.Stdout, os.Stderr = stdout, stderr
+	writer.Close()
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+	return <-output
+}
+
+func TestReplay(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	baseURL := fakePokeAPI(t).URL + "/api"
+	transcriptPath := filepath.Join(t.TempDir(), "transcript.txt")
+	commands := strings.Repeat("catch bulbasaur\n", 6) + "pokedex\nitems\n"
+
+	recorded := runSession(t, []string{"-base-url", baseURL, "-seed", "7", "-transcript", transcriptPath}, commands)
+	savePath := filepath.Join(home, "config", "pokefetch", "pokedex.json")
+	saved, err := os.ReadFile(savePath)
+	if err != nil {
+		t.Fatalf("expected the recorded session to autosave: %v", err)
+	}
+
+	for i := range 2 {
+		replayed := runSession(t, []string{"-base-url", baseURL, "-replay", transcriptPath}, "")
+		if replayed != recorded {
+			t.Fatalf("replay %d differs from the recorded session\nrecorded:\n%s\nreplayed:\n%s", i+1, recorded, replayed)
+		}
+	}
+	if strings.Count(recorded, "Throwing a poke-ball at bulbasaur") < 2 {
+		t.Errorf("expected the session to throw several balls, got:\n%s", recorded)
+	}
+	if after, err := os.ReadFile(savePath); err != nil || !bytes.Equal(after, saved) {
+		t.Errorf("expected the replays to leave the save file alone, err=%v", err)
+	}
+}
+
+func TestReplay_InvalidTranscript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transcript.txt")
+	if err := os.WriteFile(path, []byte("# seed: 7\ncatch bulbasaur\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := openReplay(path); err == nil || !strings.Contains(err.Error(), "starting save") {
+		t.Errorf("expected a missing starting save error, got %v", err)
+	}
+}
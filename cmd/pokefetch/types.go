@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/maniac-en/pokefetch/internal/typechart"
+)
+
+func commandTypes(ctx context.Context, cfg *config, param *string) error {
+	if param == nil {
+		return fmt.Errorf("can't look up the types of a pokemon with no name, please provide one")
+	}
+	pokemon, err := cfg.client.GetPokemonContext(ctx, param)
+	if err != nil {
+		return err
+	}
+	typeNames := make([]string, 0, len(pokemon.Types))
+	for _, t := range pokemon.Types {
+		typeNames = append(typeNames, t.Type.Name)
+	}
+	if err := cfg.loadTypes(ctx, typeNames...); err != nil {
+		return err
+	}
+
+	defense := cfg.typeChart.Defending(typeNames...)
+	fmt.Printf("%s (%s)\n", pokemon.Name, strings.Join(typeNames, "/"))
+	printMatchups("Weak to", defense.Weaknesses)
+	printMatchups("Resists", defense.Resistances)
+	printMatchups("Immune to", defense.Immunities)
+	return nil
+}
+
+func printMatchups(title string, matchups []typechart.Matchup) {
+	if len(matchups) == 0 {
+		return
+	}
+	fmt.Println(title + ":")
+	for _, matchup := range matchups {
+		if matchup.Multiplier == 0 {
+			fmt.Printf("  - %s\n", matchup.Type)
+			continue
+		}
+		fmt.Printf("  - %s (%sx)\n", matchup.Type, strconv.FormatFloat(matchup.Multiplier, 'g', -1, 64))
+	}
+}
+
+func commandTypechart(ctx context.Context, cfg *config, _ *string) error {
+	if err := cfg.loadTypes(ctx, typechart.Standard...); err != nil {
+		return err
+	}
+
+	fmt.Println("Attacking type (rows) against defending type (columns):")
+	fmt.Print("    ")
+	for _, defending := range typechart.Standard {
+		fmt.Printf(" %s", abbreviateType(defending))
+	}
+	fmt.Println()
+	for _, attacking := range typechart.Standard {
+		fmt.Print(abbreviateType(attacking) + " ")
+		for _, defending := range typechart.Standard {
+			fmt.Printf(" %3s", multiplierSymbol(cfg.typeChart.Multiplier(attacking, defending)))
+		}
+		fmt.Println()
+	}
+	fmt.Println("2 = super effective, ½ = not very effective, 0 = no effect, . = normal")
+	return nil
+}
+
+// loadTypes fetches the types missing from the type chart
+func (cfg *config) loadTypes(ctx context.Context, typeNames ...string) error {
+	for _, typeName := range typeNames {
+		if cfg.typeChart.Has(typeName) {
+			continue
+		}
+		t, err := cfg.client.GetTypeContext(ctx, &typeName)
+		if err != nil {
+			return err
+		}
+		cfg.typeChart.Add(t)
+	}
+	return nil
+}
+
+// abbreviateType shortens a type name to the 3 letters the chart's columns fit
+func abbreviateType(typeName string) string {
+	return strings.ToUpper(typeName[:min(3, len(typeName))])
+}
+
+func multiplierSymbol(multiplier float64) string {
+	switch multiplier {
+	case 0:
+		return "0"
+	case 0.5:
+		return "½"
+	case 2:
+		return "2"
+	default:
+		return "."
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+// allVersions clears the version setting
+const allVersions string = "all"
+
+func commandVersion(ctx context.Context, cfg *config, param *string) error {
+	if param == nil {
+		if cfg.version == nil {
+			fmt.Println("Showing every game version, pick one like \"version red\"")
+		} else {
+			fmt.Printf("Showing pokemon %s only (%s)\n", cfg.version.Name, cfg.version.VersionGroup.Name)
+		}
+		return nil
+	}
+	versionName := resourceName(*param)
+	if versionName == allVersions {
+		cfg.setVersion(nil)
+		fmt.Println("Showing every game version")
+		return nil
+	}
+	version, err := cfg.client.GetVersionContext(ctx, &versionName)
+	if err != nil {
+		return err
+	}
+	cfg.setVersion(&version)
+	fmt.Printf("Showing pokemon %s only, use \"version %s\" to go back to every game\n", version.Name, allVersions)
+	return nil
+}
+
+// setVersion switches the game version, leaving the explored area and the wild
+// pokemon behind as they were met in the previous one
+func (cfg *config) setVersion(version *client.Version) {
+	previous := cfg.versionName()
+	cfg.version = version
+	if cfg.versionName() != previous {
+		cfg.exploredArea = nil
+		cfg.wild = nil
+	}
+}
+
+// versionName is the game version the session is filtered to, "" for all
+func (cfg *config) versionName() string {
+	if cfg.version == nil {
+		return ""
+	}
+	return cfg.version.Name
+}
+
+// inVersion reports whether data of the game version shows in the session
+func (cfg *config) inVersion(version string) bool {
+	return cfg.version == nil || cfg.version.Name == version
+}
+
+// versionGroupName is the version group of the game version the session is
+// filtered to, e.g. red-blue for red, "" for all
+func (cfg *config) versionGroupName() string {
+	if cfg.version == nil {
+		return ""
+	}
+	return cfg.version.VersionGroup.Name
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+func TestSetVersion(t *testing.T) {
+	red := &client.Version{Name: "red"}
+	cfg := &config{
+		exploredArea: &client.PokeMapArea{Name: "viridian-forest-area"},
+		wild:         &wildPokemon{pokemon: client.Pokemon{Name: "pikachu"}},
+	}
+
+	cfg.setVersion(red)
+	if cfg.exploredArea != nil || cfg.wild != nil {
+		t.Errorf("expected switching version to leave the area and the wild pokemon behind")
+	}
+
+	cfg.exploredArea = &client.PokeMapArea{Name: "viridian-forest-area"}
+	cfg.wild = &wildPokemon{pokemon: client.Pokemon{Name: "caterpie"}}
+	cfg.setVersion(&client.Version{Name: "red"})
+	if cfg.exploredArea == nil || cfg.wild == nil {
+		t.Errorf("expected picking the same version again to keep the area and the wild pokemon")
+	}
+
+	cfg.setVersion(nil)
+	if cfg.version != nil || cfg.exploredArea != nil || cfg.wild != nil {
+		t.Errorf("expected going back to every version to leave the area and the wild pokemon behind")
+	}
+}
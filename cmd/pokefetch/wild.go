@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/maniac-en/pokefetch/internal/catch"
+	"github.com/maniac-en/pokefetch/internal/client"
+	"github.com/maniac-en/pokefetch/internal/encounter"
+)
+
+// wildPokemon is the pokemon met with the last encounter, until it's caught
+type wildPokemon struct {
+	pokemon client.Pokemon
+	species client.PokemonSpecies
+	level   int
+}
+
+func commandEncounter(ctx context.Context, cfg *config, param *string) error {
+	if cfg.exploredArea == nil {
+		return fmt.Errorf("there's nowhere to look for pokemons, explore an area first")
+	}
+	slots := encounter.ForVersion(encounter.Slots(*cfg.exploredArea), cfg.versionName())
+	methods := encounter.Methods(slots)
+	if len(methods) == 0 {
+		if cfg.version != nil {
+			return fmt.Errorf("no pokemon lives in %s in pokemon %s", cfg.exploredArea.Name, cfg.version.Name)
+		}
+		return fmt.Errorf("no pokemon lives in %s", cfg.exploredArea.Name)
+	}
+	method := encounter.DefaultMethod
+	if param != nil {
+		method = resourceName(*param)
+	} else if !slices.Contains(methods, method) {
+		method = methods[0]
+	}
+	rolled, ok := encounter.Roll(cfg.rng, slots, method)
+	if !ok {
+		return fmt.Errorf("no pokemon can be met by %s in %s, try one of: %s",
+			method, cfg.exploredArea.Name, strings.Join(methods, ", "))
+	}
+
+	pokemon, err := cfg.client.GetPokemonContext(ctx, &rolled.Pokemon)
+	if err != nil {
+		return err
+	}
+	species, err := cfg.client.GetPokemonSpeciesContext(ctx, speciesNameOf(pokemon))
+	if err != nil {
+		return err
+	}
+	cfg.wild = &wildPokemon{pokemon: pokemon, species: species, level: rolled.Level}
+	fmt.Printf("A wild %s appeared! Level %d, met by %s\n", pokemon.Name, rolled.Level, method)
+	fmt.Println("Try to catch it with the catch command")
+	return nil
+}
+
+func commandWalk(ctx context.Context, cfg *config, _ *string) error {
+	method := encounter.DefaultMethod
+	return commandEncounter(ctx, cfg, &method)
+}
+
+// target is the wild pokemon as a healthy one of its level
+func (wild *wildPokemon) target() catch.Target {
+	target := catchTarget(wild.pokemon, wild.species)
+	// the games' HP formula, without the individual and effort values
+	hp := 2*target.MaxHP*wild.level/100 + wild.level + 10
+	target.MaxHP, target.HP = hp, hp
+	return target
+}
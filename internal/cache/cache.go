@@ -3,65 +3,362 @@
 package cache
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
 
 type cacheEntry struct {
-	createdAt time.Time
-	val       []byte
+	createdAt  time.Time
+	expiresAt  time.Time
+	val        []byte
+	validators Validators
+	// elem is the entry's position in the recency list, its value is the key
+	elem *list.Element
+}
+
+func (e cacheEntry) size(key string) int {
+	return len(key) + len(e.val)
+}
+
+// EntryOption configures a single entry added to a Cache
+type EntryOption func(*cacheEntry)
+
+// Validators are the HTTP validators of a cached response, they allow to check
+// whether an expired entry is still current without downloading it again
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// WithValidators stores the HTTP validators of the response along the entry
+func WithValidators(validators Validators) EntryOption {
+	return func(e *cacheEntry) {
+		e.validators = validators
+	}
+}
+
+// WithTTL overrides the cache's TTL for a single entry
+func WithTTL(ttl time.Duration) EntryOption {
+	return func(e *cacheEntry) {
+		e.expiresAt = e.createdAt.Add(ttl)
+	}
 }
 
 type Cache struct {
 	entries map[string]cacheEntry
 	mu      *sync.RWMutex
 	ttl     time.Duration
+	// staleRetention keeps the expired entries around for GetStale this much
+	// longer before the reaper purges them
+	staleRetention time.Duration
+	// validatedRetention keeps the expired entries with HTTP validators even
+	// longer, they can be revalidated cheaply with a conditional request
+	validatedRetention time.Duration
+	// disk is optional, when set every entry is written through to it and
+	// misses are looked up there, so the cache survives restarts
+	disk *diskStore
+	// recency orders the keys from the most to the least recently used one,
+	// it's only used to pick what to evict once a budget below is exceeded
+	recency    *list.List
+	bytes      int
+	maxEntries int
+	maxBytes   int
+	counters   counters
+	// done stops the reaper goroutine, reaperDone reports it has returned
+	done       chan struct{}
+	reaperDone chan struct{}
+	closeOnce  *sync.Once
+}
+
+// Option configures the optional parts of a Cache
+type Option func(*Cache)
+
+// WithMaxEntries bounds the number of entries kept in memory, the least
+// recently used ones are evicted first
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+// WithMaxBytes bounds the size of the keys and values kept in memory, the least
+// recently used ones are evicted first
+func WithMaxBytes(n int) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+// WithStaleRetention keeps the expired entries available to GetStale for d
+// after they expire, Get never returns them
+func WithStaleRetention(d time.Duration) Option {
+	return func(c *Cache) {
+		c.staleRetention = d
+	}
+}
+
+// WithValidatedRetention keeps the expired entries which have HTTP validators
+// for d after they expire, when it's longer than the stale retention, so they
+// can still be revalidated long after the other expired entries are purged
+func WithValidatedRetention(d time.Duration) Option {
+	return func(c *Cache) {
+		c.validatedRetention = d
+	}
 }
 
 func (c *Cache) GetTTL() int {
 	return int(c.ttl.Minutes())
 }
 
-func (c *Cache) Add(key string, val []byte) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.entries[key] = cacheEntry{
-		createdAt: time.Now(),
+// Add stores val under key until the cache's TTL runs out, unless another TTL
+// is given for this entry with WithTTL
+func (c *Cache) Add(key string, val []byte, opts ...EntryOption) {
+	now := time.Now()
+	entry := cacheEntry{
+		createdAt: now,
+		expiresAt: now.Add(c.ttl),
 		val:       val,
 	}
+	for _, opt := range opts {
+		opt(&entry)
+	}
+	c.mu.Lock()
+	c.set(key, entry)
+	c.mu.Unlock()
+	if c.disk != nil {
+		// a failed write only costs a refetch after the next restart
+		_ = c.disk.write(key, entry)
+	}
 }
 
 func (c *Cache) Get(key string) ([]byte, bool) {
+	now := time.Now()
+	entry, ok := c.lookup(key, now)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !ok || c.isExpired(entry, now) {
+		c.counters.misses++
+		return nil, false
+	}
+	c.counters.hits++
+	return entry.val, true
+}
+
+// GetStale is like Get, but it also returns the expired entries still kept
+// around by WithStaleRetention, reporting whether the value is still fresh
+func (c *Cache) GetStale(key string) (val []byte, fresh bool, ok bool) {
+	now := time.Now()
+	entry, ok := c.lookup(key, now)
+	if !ok || !now.Before(entry.expiresAt.Add(c.staleRetention)) {
+		return nil, false, false
+	}
+	fresh = !c.isExpired(entry, now)
+	if !fresh {
+		c.mu.Lock()
+		c.counters.staleHits++
+		c.mu.Unlock()
+	}
+	return entry.val, fresh, true
+}
+
+// GetValidated returns an entry along with its HTTP validators, expired
+// entries kept around by WithStaleRetention or WithValidatedRetention included
+func (c *Cache) GetValidated(key string) (val []byte, validators Validators, ok bool) {
+	entry, ok := c.lookup(key, time.Now())
+	if !ok {
+		return nil, Validators{}, false
+	}
+	return entry.val, entry.validators, true
+}
+
+// lookup returns the entry of key, from memory or else from the disk, unless
+// it's past its retention
+func (c *Cache) lookup(key string, now time.Time) (cacheEntry, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok {
+		c.recency.MoveToFront(entry.elem)
+	}
+	c.mu.Unlock()
+	if !ok && c.disk != nil {
+		entry, ok = c.loadFromDisk(key, now)
+	}
+	if !ok || c.isPurgeable(entry, now) {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// loadFromDisk promotes an entry persisted on disk back into memory
+func (c *Cache) loadFromDisk(key string, now time.Time) (cacheEntry, bool) {
+	entry, ok := c.disk.read(key)
+	if !ok {
+		return cacheEntry{}, false
+	}
+	entry = c.withDefaultExpiry(entry)
+	if c.isPurgeable(entry, now) {
+		c.disk.remove(key)
+		return cacheEntry{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, entry)
+	return entry, true
+}
+
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	c.remove(key)
+	c.mu.Unlock()
+	if c.disk != nil {
+		c.disk.remove(key)
+	}
+}
+
+func (c *Cache) Len() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Close stops the reaper and drops the in memory entries, the ones persisted
+// on disk are kept for the next run. It's safe to call Close more than once.
+func (c *Cache) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		<-c.reaperDone
+	})
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.recency.Init()
+	c.bytes = 0
+	return nil
+}
+
+// set stores the entry as the most recently used one and evicts whatever goes
+// over the budget, callers must hold the write lock
+func (c *Cache) set(key string, entry cacheEntry) {
+	c.remove(key)
+	if c.maxBytes > 0 && entry.size(key) > c.maxBytes {
+		// it would evict everything else and still not fit
+		return
+	}
+	entry.elem = c.recency.PushFront(key)
+	c.entries[key] = entry
+	c.bytes += entry.size(key)
+	for c.overBudget() {
+		c.remove(c.recency.Back().Value.(string))
+		c.counters.evictions++
+	}
+}
+
+// remove drops the entry from memory only, evicted entries are still served
+// from the disk when there's one
+func (c *Cache) remove(key string) {
 	entry, ok := c.entries[key]
-	return entry.val, ok
+	if !ok {
+		return
+	}
+	c.recency.Remove(entry.elem)
+	c.bytes -= entry.size(key)
+	delete(c.entries, key)
+}
+
+func (c *Cache) overBudget() bool {
+	return (c.maxEntries > 0 && len(c.entries) > c.maxEntries) ||
+		(c.maxBytes > 0 && c.bytes > c.maxBytes)
+}
+
+func (c *Cache) isExpired(entry cacheEntry, now time.Time) bool {
+	return !now.Before(entry.expiresAt)
+}
+
+// isPurgeable reports whether the entry is past even its retention, the stale
+// one or the validated one when it has HTTP validators
+func (c *Cache) isPurgeable(entry cacheEntry, now time.Time) bool {
+	retention := c.staleRetention
+	if entry.validators != (Validators{}) {
+		retention = max(retention, c.validatedRetention)
+	}
+	return !now.Before(entry.expiresAt.Add(retention))
+}
+
+// withDefaultExpiry makes the entries persisted without their own expiry
+// expire with the cache's TTL
+func (c *Cache) withDefaultExpiry(entry cacheEntry) cacheEntry {
+	if entry.expiresAt.IsZero() {
+		entry.expiresAt = entry.createdAt.Add(c.ttl)
+	}
+	return entry
 }
 
 func (c *Cache) removeExpired() {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
 	for key, val := range c.entries {
-		if val.createdAt.Compare(time.Now().Add(-c.ttl)) >= 0 {
-			delete(c.entries, key)
+		if c.isPurgeable(val, now) {
+			c.remove(key)
+			c.counters.expirations++
+			if c.disk != nil {
+				c.disk.remove(key)
+			}
 		}
 	}
 }
 
-func NewCache(interval time.Duration) Cache {
+func NewCache(interval time.Duration, opts ...Option) *Cache {
+	return newCache(interval, nil, opts...)
+}
+
+func newCache(interval time.Duration, disk *diskStore, opts ...Option) *Cache {
 	// create a new cache with a configurable interval
 	// and purge it from cache when interval passes
-	cache := Cache{
-		entries: make(map[string]cacheEntry),
-		mu:      &sync.RWMutex{},
-		ttl:     interval,
-	}
-	go func() {
-		ticker := time.NewTicker(cache.ttl)
-		defer ticker.Stop()
-		for range ticker.C {
-			cache.removeExpired()
-		}
-	}()
+	cache := &Cache{
+		entries:    make(map[string]cacheEntry),
+		mu:         &sync.RWMutex{},
+		ttl:        interval,
+		disk:       disk,
+		recency:    list.New(),
+		done:       make(chan struct{}),
+		reaperDone: make(chan struct{}),
+		closeOnce:  &sync.Once{},
+	}
+	for _, opt := range opts {
+		opt(cache)
+	}
+	go cache.reap()
 	return cache
 }
+
+// reap purges the expired entries every ttl until the cache is closed
+func (c *Cache) reap() {
+	defer close(c.reaperDone)
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.removeExpired()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// NewDiskCache creates a cache which is persisted under dir, entries written
+// by a previous run are served until their TTL runs out
+func NewDiskCache(interval time.Duration, dir string, opts ...Option) (*Cache, error) {
+	disk, err := newDiskStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	cache := newCache(interval, disk, opts...)
+	now := time.Now()
+	disk.prune(func(entry cacheEntry) bool {
+		return cache.isPurgeable(cache.withDefaultExpiry(entry), now)
+	})
+	return cache, nil
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"runtime"
 	"testing"
 	"time"
 )
@@ -50,7 +51,279 @@ func TestCache_removeExpired(t *testing.T) {
 		t.Errorf("expected to find %s", key)
 	}
 	time.Sleep(expiryTime * 2)
-	if _, ok := cache.Get(key); !ok {
+	if _, ok := cache.Get(key); ok {
 		t.Errorf("expected to not find %s", key)
 	}
 }
+
+func TestCache_DeleteAndLen(t *testing.T) {
+	cache := NewCache(time.Minute)
+	cache.Add("https://example.com/1", []byte("bulbasaur"))
+	cache.Add("https://example.com/2", []byte("ivysaur"))
+
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+	cache.Delete("https://example.com/1")
+	if _, ok := cache.Get("https://example.com/1"); ok {
+		t.Errorf("expected deleted entry to be gone")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", cache.Len())
+	}
+	if err := cache.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected no entries after close, got %d", cache.Len())
+	}
+}
+
+func TestNop(t *testing.T) {
+	var store Store = Nop{}
+	store.Add("https://example.com", []byte("pikapika"))
+	if _, ok := store.Get("https://example.com"); ok {
+		t.Errorf("expected Nop to never hit")
+	}
+	if store.Len() != 0 {
+		t.Errorf("expected Nop to be empty, got %d", store.Len())
+	}
+}
+
+func TestCache_LRUEviction(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []Option
+		keys            []string
+		touch           string
+		expectedEvicted []string
+		expectedKept    []string
+	}{
+		{
+			name:            "max entries evicts the oldest",
+			opts:            []Option{WithMaxEntries(2)},
+			keys:            []string{"a", "b", "c"},
+			expectedEvicted: []string{"a"},
+			expectedKept:    []string{"b", "c"},
+		},
+		{
+			name:            "max entries keeps the recently read",
+			opts:            []Option{WithMaxEntries(2)},
+			keys:            []string{"a", "b", "c"},
+			touch:           "a",
+			expectedEvicted: []string{"b"},
+			expectedKept:    []string{"a", "c"},
+		},
+		{
+			// every entry is 1 byte of key and 4 bytes of value
+			name:            "max bytes evicts until it fits",
+			opts:            []Option{WithMaxBytes(12)},
+			keys:            []string{"a", "b", "c"},
+			expectedEvicted: []string{"a"},
+			expectedKept:    []string{"b", "c"},
+		},
+		{
+			name:            "value over the max bytes is never kept",
+			opts:            []Option{WithMaxBytes(4)},
+			keys:            []string{"a"},
+			expectedEvicted: []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewCache(time.Minute, tt.opts...)
+			for i, key := range tt.keys {
+				cache.Add(key, []byte("pika"))
+				// touch right after the first two keys are in, before any eviction
+				if i == 1 && tt.touch != "" {
+					cache.Get(tt.touch)
+				}
+			}
+			for _, key := range tt.expectedEvicted {
+				if _, ok := cache.Get(key); ok {
+					t.Errorf("expected %s to be evicted", key)
+				}
+			}
+			for _, key := range tt.expectedKept {
+				if _, ok := cache.Get(key); !ok {
+					t.Errorf("expected %s to be kept", key)
+				}
+			}
+			if cache.Len() != len(tt.expectedKept) {
+				t.Errorf("expected %d entries, got %d", len(tt.expectedKept), cache.Len())
+			}
+		})
+	}
+}
+
+func TestCache_LRUEvictionKeepsDisk(t *testing.T) {
+	cache, err := NewDiskCache(time.Minute, t.TempDir(), WithMaxEntries(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache.Add("a", []byte("bulbasaur"))
+	cache.Add("b", []byte("ivysaur"))
+
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 entry in memory, got %d", cache.Len())
+	}
+	if val, ok := cache.Get("a"); !ok || string(val) != "bulbasaur" {
+		t.Errorf("expected evicted entry to be served from disk, got %q", val)
+	}
+}
+
+func TestCache_CloseStopsReaper(t *testing.T) {
+	before := runtime.NumGoroutine()
+	caches := make([]*Cache, 10)
+	for i := range caches {
+		caches[i] = NewCache(time.Millisecond)
+	}
+	if runtime.NumGoroutine() < before+len(caches) {
+		t.Fatalf("expected a reaper per cache, got %d goroutines from %d", runtime.NumGoroutine(), before)
+	}
+
+	for _, cache := range caches {
+		if err := cache.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// closing twice must not panic or block
+		if err := cache.Close(); err != nil {
+			t.Fatalf("unexpected error on second close: %v", err)
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected %d goroutines after close, got %d", before, after)
+	}
+}
+
+func TestCache_PerEntryTTL(t *testing.T) {
+	expiryTime := 5 * time.Millisecond
+	cache := NewCache(time.Minute)
+	defer cache.Close()
+	cache.Add("short", []byte("magikarp"), WithTTL(expiryTime))
+	cache.Add("long", []byte("gyarados"))
+
+	time.Sleep(expiryTime * 2)
+	if _, ok := cache.Get("short"); ok {
+		t.Errorf("expected entry with a short TTL to have expired")
+	}
+	if _, ok := cache.Get("long"); !ok {
+		t.Errorf("expected entry with the default TTL to still be fresh")
+	}
+}
+
+func TestCache_GetStale(t *testing.T) {
+	expiryTime := 5 * time.Millisecond
+	cache := NewCache(time.Minute, WithStaleRetention(time.Minute))
+	defer cache.Close()
+	cache.Add("key", []byte("eevee"), WithTTL(expiryTime))
+
+	if val, fresh, ok := cache.GetStale("key"); !ok || !fresh || string(val) != "eevee" {
+		t.Errorf("expected a fresh entry, got %q fresh=%v ok=%v", val, fresh, ok)
+	}
+	time.Sleep(expiryTime * 2)
+	if _, ok := cache.Get("key"); ok {
+		t.Errorf("expected Get to skip the expired entry")
+	}
+	if val, fresh, ok := cache.GetStale("key"); !ok || fresh || string(val) != "eevee" {
+		t.Errorf("expected a stale entry, got %q fresh=%v ok=%v", val, fresh, ok)
+	}
+	if _, _, ok := cache.GetStale("missing"); ok {
+		t.Errorf("expected missing key to not be found")
+	}
+}
+
+func TestCache_ValidatedRetention(t *testing.T) {
+	expiryTime := 5 * time.Millisecond
+	validators := Validators{ETag: `"v1"`}
+	cache := NewCache(time.Minute, WithValidatedRetention(time.Minute))
+	defer cache.Close()
+	cache.Add("validated", []byte("eevee"), WithTTL(expiryTime), WithValidators(validators))
+	cache.Add("plain", []byte("eevee"), WithTTL(expiryTime))
+
+	time.Sleep(expiryTime * 2)
+	cache.removeExpired()
+	if _, _, ok := cache.GetValidated("plain"); ok {
+		t.Errorf("expected the expired entry without validators to be purged")
+	}
+	if val, actual, ok := cache.GetValidated("validated"); !ok || actual != validators || string(val) != "eevee" {
+		t.Errorf("expected the expired entry with validators to be kept, got %q %+v ok=%v", val, actual, ok)
+	}
+	if _, _, ok := cache.GetStale("validated"); ok {
+		t.Errorf("expected GetStale to skip the entry past its stale retention")
+	}
+}
+
+func TestCache_Stats(t *testing.T) {
+	cache := NewCache(time.Minute, WithMaxEntries(2))
+	defer cache.Close()
+
+	if stats := cache.Stats(); stats != (Stats{}) {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+
+	cache.Add("a", []byte("pika"))
+	cache.Add("b", []byte("pika"))
+	cache.Add("c", []byte("pika"))
+	cache.Get("b")
+	cache.Get("c")
+	cache.Get("a")
+
+	stats := cache.Stats()
+	expected := Stats{Hits: 2, Misses: 1, Evictions: 1, Entries: 2, Bytes: 10}
+	stats.OldestEntryAge = 0
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+	if ratio := stats.HitRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("expected a hit ratio of 2/3, got %f", ratio)
+	}
+}
+
+func TestCache_StatsStaleHits(t *testing.T) {
+	expiryTime := 5 * time.Millisecond
+	cache := NewCache(time.Minute, WithStaleRetention(time.Minute))
+	defer cache.Close()
+	cache.Add("fresh", []byte("pika"))
+	cache.Add("stale", []byte("pika"), WithTTL(expiryTime))
+	time.Sleep(expiryTime * 2)
+
+	cache.Get("fresh")
+	// served stale the way the client does it, after Get misses
+	if _, ok := cache.Get("stale"); !ok {
+		cache.GetStale("stale")
+	}
+	cache.Get("missing")
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.StaleHits != 1 || stats.Misses != 2 {
+		t.Errorf("expected 1 hit, 1 stale hit and 2 misses, got %+v", stats)
+	}
+	if ratio := stats.HitRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("expected the stale hit to count in a hit ratio of 2/3, got %f", ratio)
+	}
+}
+
+func TestCache_KeysAndClear(t *testing.T) {
+	cache, err := NewDiskCache(time.Minute, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cache.Close()
+	cache.Add("b", []byte("ivysaur"))
+	cache.Add("a", []byte("bulbasaur"))
+
+	keys := cache.Keys()
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("expected sorted keys [a b], got %v", keys)
+	}
+
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("expected no entries after clear, got %d", cache.Len())
+	}
+	if _, ok := cache.Get("a"); ok {
+		t.Errorf("expected clear to also drop the entries on disk")
+	}
+}
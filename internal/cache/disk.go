@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	diskEntryExt string = ".json"
+	diskTempExt  string = ".tmp"
+)
+
+// diskStore keeps one file per cache key, every file is written to a temp file
+// and renamed into place, and carries a checksum of the value, so an entry
+// is either read back whole or not at all
+type diskStore struct {
+	dir string
+}
+
+type diskEntry struct {
+	Key          string    `json:"key"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Checksum     string    `json:"checksum"`
+	Val          []byte    `json:"val"`
+}
+
+func newDiskStore(dir string) (*diskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	return &diskStore{dir: dir}, nil
+}
+
+func checksum(val []byte) string {
+	sum := sha256.Sum256(val)
+	return hex.EncodeToString(sum[:])
+}
+
+func (d *diskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+diskEntryExt)
+}
+
+func (d *diskStore) write(key string, entry cacheEntry) error {
+	data, err := json.Marshal(diskEntry{
+		Key:          key,
+		CreatedAt:    entry.createdAt,
+		ExpiresAt:    entry.expiresAt,
+		ETag:         entry.validators.ETag,
+		LastModified: entry.validators.LastModified,
+		Checksum:     checksum(entry.val),
+		Val:          entry.val,
+	})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(d.dir, "entry-*"+diskTempExt)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), d.path(key))
+}
+
+// readFile decodes and verifies a single entry file
+func (d *diskStore) readFile(path string) (diskEntry, error) {
+	var entry diskEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, fmt.Errorf("corrupt cache entry %s: %w", path, err)
+	}
+	if entry.Checksum != checksum(entry.Val) {
+		return entry, fmt.Errorf("corrupt cache entry %s: checksum mismatch", path)
+	}
+	return entry, nil
+}
+
+// read returns the entry for key, unreadable or foreign entries are removed
+// so they're never served
+func (d *diskStore) read(key string) (cacheEntry, bool) {
+	path := d.path(key)
+	entry, err := d.readFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			os.Remove(path)
+		}
+		return cacheEntry{}, false
+	}
+	if entry.Key != key {
+		return cacheEntry{}, false
+	}
+	return entry.cacheEntry(), true
+}
+
+func (e diskEntry) cacheEntry() cacheEntry {
+	return cacheEntry{
+		createdAt: e.CreatedAt,
+		expiresAt: e.ExpiresAt,
+		val:       e.Val,
+		validators: Validators{
+			ETag:         e.ETag,
+			LastModified: e.LastModified,
+		},
+	}
+}
+
+func (d *diskStore) remove(key string) {
+	os.Remove(d.path(key))
+}
+
+// clear removes every entry file
+func (d *diskStore) clear() {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), diskEntryExt) {
+			os.Remove(filepath.Join(d.dir, file.Name()))
+		}
+	}
+}
+
+// prune removes the purgeable and corrupt entries, along with the temp files
+// left behind by a crash in the middle of a write
+func (d *diskStore) prune(purgeable func(cacheEntry) bool) {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		path := filepath.Join(d.dir, file.Name())
+		switch {
+		case file.IsDir():
+			continue
+		case strings.HasSuffix(file.Name(), diskTempExt):
+			os.Remove(path)
+		case strings.HasSuffix(file.Name(), diskEntryExt):
+			entry, err := d.readFile(path)
+			if err != nil {
+				os.Remove(path)
+				continue
+			}
+			if purgeable(entry.cacheEntry()) {
+				os.Remove(path)
+			}
+		}
+	}
+}
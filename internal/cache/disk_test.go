@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiskCache_SurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	key := "https://example.com"
+
+	first, err := NewDiskCache(time.Minute, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Add(key, []byte("pikapika"))
+
+	second, err := NewDiskCache(time.Minute, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	val, ok := second.Get(key)
+	if !ok {
+		t.Fatalf("expected to find %s after restart", key)
+	}
+	if string(val) != "pikapika" {
+		t.Errorf("expected %q, got %q", "pikapika", val)
+	}
+}
+
+func TestDiskCache_KeepsValidators(t *testing.T) {
+	dir := t.TempDir()
+	key := "https://example.com"
+	validators := Validators{ETag: `"v1"`, LastModified: "Wed, 21 Oct 2015 07:28:00 GMT"}
+
+	first, err := NewDiskCache(time.Minute, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer first.Close()
+	first.Add(key, []byte("pikapika"), WithValidators(validators))
+
+	second, err := NewDiskCache(time.Minute, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer second.Close()
+	if _, ok := second.Get(key); !ok {
+		t.Fatalf("expected to find %s after restart", key)
+	}
+	if _, actual, ok := second.GetValidated(key); !ok || actual != validators {
+		t.Errorf("expected validators %+v, got %+v", validators, actual)
+	}
+}
+
+func TestDiskCache_ExpiredAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+	key := "https://example.com"
+	expiryTime := 5 * time.Millisecond
+
+	first, err := NewDiskCache(time.Minute, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Add(key, []byte("pikapika"), WithTTL(expiryTime))
+	time.Sleep(expiryTime * 2)
+
+	second, err := NewDiskCache(time.Minute, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := second.Get(key); ok {
+		t.Errorf("expected %s to have expired", key)
+	}
+	if _, err := os.Stat(first.disk.path(key)); !os.IsNotExist(err) {
+		t.Errorf("expected expired entry file to be removed, got %v", err)
+	}
+}
+
+func TestDiskCache_ValidatedAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+	key := "https://example.com"
+	expiryTime := 5 * time.Millisecond
+
+	first, err := NewDiskCache(time.Minute, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Add(key, []byte("pikapika"), WithTTL(expiryTime), WithValidators(Validators{ETag: `"v1"`}))
+	time.Sleep(expiryTime * 2)
+
+	second, err := NewDiskCache(time.Minute, dir, WithValidatedRetention(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := second.Get(key); ok {
+		t.Errorf("expected %s to have expired", key)
+	}
+	if _, validators, ok := second.GetValidated(key); !ok || validators.ETag != `"v1"` {
+		t.Errorf("expected %s to be kept for revalidation, got %+v ok=%v", key, validators, ok)
+	}
+}
+
+func TestDiskCache_CorruptEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(data []byte) []byte
+	}{
+		{
+			name: "truncated entry",
+			corrupt: func(data []byte) []byte {
+				return data[:len(data)/2]
+			},
+		},
+		{
+			name: "checksum mismatch",
+			corrupt: func(data []byte) []byte {
+				// "cGlrYXBpa2E=" is base64 for "pikapika"
+				return []byte(strings.Replace(string(data), "cGlrYXBpa2E=", "cGlrYWNodQ==", 1))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			key := "https://example.com"
+
+			first, err := NewDiskCache(time.Minute, dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			first.Add(key, []byte("pikapika"))
+
+			path := first.disk.path(key)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := os.WriteFile(path, tt.corrupt(data), 0o644); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			second := newCache(time.Minute, first.disk)
+			defer second.Close()
+			if val, ok := second.Get(key); ok {
+				t.Errorf("expected corrupt entry to be skipped, got %q", val)
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("expected corrupt entry file to be removed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDiskCache_PrunesLeftovers(t *testing.T) {
+	dir := t.TempDir()
+	leftover := filepath.Join(dir, "entry-123"+diskTempExt)
+	if err := os.WriteFile(leftover, []byte(`{"key":`), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := NewDiskCache(time.Minute, dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Errorf("expected leftover temp file to be removed, got %v", err)
+	}
+}
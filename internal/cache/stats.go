@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"sort"
+	"time"
+)
+
+// counters are the running totals behind Stats, guarded by the cache's mutex
+type counters struct {
+	hits        uint64
+	misses      uint64
+	staleHits   uint64
+	evictions   uint64
+	expirations uint64
+}
+
+// Stats is a snapshot of how effective a Cache is
+type Stats struct {
+	// Hits and Misses count the Get calls which found a fresh entry or not,
+	// StaleHits the expired entries then served by GetStale, which are meant
+	// to be looked up after a Get miss and so are counted among the misses too
+	Hits      uint64
+	Misses    uint64
+	StaleHits uint64
+	// Evictions counts the entries dropped to stay within the size budget,
+	// Expirations the ones purged by the reaper once their TTL ran out
+	Evictions   uint64
+	Expirations uint64
+	Entries     int
+	Bytes       int
+	// OldestEntryAge is zero when the cache is empty
+	OldestEntryAge time.Duration
+}
+
+// HitRatio returns the share of lookups answered from the cache, the stale
+// hits included as they spare waiting on a refetch as much as the fresh ones
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return min(float64(s.Hits+s.StaleHits)/float64(total), 1)
+}
+
+func (c *Cache) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats := Stats{
+		Hits:        c.counters.hits,
+		Misses:      c.counters.misses,
+		StaleHits:   c.counters.staleHits,
+		Evictions:   c.counters.evictions,
+		Expirations: c.counters.expirations,
+		Entries:     len(c.entries),
+		Bytes:       c.bytes,
+	}
+	now := time.Now()
+	for _, entry := range c.entries {
+		stats.OldestEntryAge = max(stats.OldestEntryAge, now.Sub(entry.createdAt))
+	}
+	return stats
+}
+
+// Keys returns the keys held in memory, sorted
+func (c *Cache) Keys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Clear drops every entry, the ones persisted on disk included, the counters
+// are kept
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.recency.Init()
+	c.bytes = 0
+	if c.disk != nil {
+		c.disk.clear()
+	}
+}
@@ -0,0 +1,48 @@
+package cache
+
+// Store is the behaviour expected from a responses cache, *Cache is the
+// default in memory (and optionally disk backed) implementation
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Add(key string, val []byte, opts ...EntryOption)
+	Delete(key string)
+	Len() int
+	Close() error
+}
+
+// StaleStore is a Store which can also hand out expired entries, allowing its
+// users to serve them while they're being refreshed
+type StaleStore interface {
+	Store
+	GetStale(key string) (val []byte, fresh bool, ok bool)
+}
+
+// ValidatingStore is a StaleStore which also keeps the HTTP validators of its
+// entries, allowing its users to revalidate them with conditional requests
+type ValidatingStore interface {
+	StaleStore
+	GetValidated(key string) (val []byte, validators Validators, ok bool)
+}
+
+// Inspector is a Store which can report on and manage its content
+type Inspector interface {
+	Store
+	Stats() Stats
+	Keys() []string
+	Clear()
+}
+
+var (
+	_ ValidatingStore = (*Cache)(nil)
+	_ Inspector       = (*Cache)(nil)
+	_ Store           = Nop{}
+)
+
+// Nop is a Store which never holds anything, every lookup misses
+type Nop struct{}
+
+func (Nop) Get(string) ([]byte, bool)          { return nil, false }
+func (Nop) Add(string, []byte, ...EntryOption) {}
+func (Nop) Delete(string)                      {}
+func (Nop) Len() int                           { return 0 }
+func (Nop) Close() error                       { return nil }
@@ -0,0 +1,151 @@
+// Package catch decides whether a thrown Poke Ball catches a pokemon, after
+// the formula of the third and fourth generation games
+package catch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var ErrUnknownBall = errors.New("unknown ball")
+
+// RNG is the source of randomness of a throw, *rand.Rand satisfies it
+type RNG interface {
+	// IntN returns a number in [0, n)
+	IntN(n int) int
+}
+
+// Ball is a Poke Ball, named after its PokeAPI item
+type Ball string
+
+const (
+	PokeBall   Ball = "poke-ball"
+	GreatBall  Ball = "great-ball"
+	UltraBall  Ball = "ultra-ball"
+	MasterBall Ball = "master-ball"
+)
+
+// Balls lists the balls from the weakest to the strongest one
+var Balls = []Ball{PokeBall, GreatBall, UltraBall, MasterBall}
+
+// ParseBall accepts both the item name, e.g. "great-ball", and the short one,
+// e.g. "great"
+func ParseBall(name string) (Ball, error) {
+	name = strings.ToLower(name)
+	for _, ball := range Balls {
+		if name == string(ball) || name+"-ball" == string(ball) {
+			return ball, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownBall, name)
+}
+
+func (ball Ball) modifier() float64 {
+	switch ball {
+	case GreatBall:
+		return 1.5
+	case UltraBall:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// Status is the status condition of the target
+type Status string
+
+const (
+	Healthy   Status = ""
+	Asleep    Status = "sleep"
+	Frozen    Status = "freeze"
+	Paralyzed Status = "paralysis"
+	Poisoned  Status = "poison"
+	Burned    Status = "burn"
+)
+
+func (status Status) modifier() float64 {
+	switch status {
+	case Asleep, Frozen:
+		return 2
+	case Paralyzed, Poisoned, Burned:
+		return 1.5
+	default:
+		return 1
+	}
+}
+
+// Target is the pokemon a ball is thrown at
+type Target struct {
+	// CaptureRate is the species' capture rate, from 3 for the legendaries
+	// up to 255 for the likes of caterpie
+	CaptureRate int
+	MaxHP       int
+	HP          int
+	Status      Status
+}
+
+// Result tells how a throw went, the ball shakes up to 3 times before the
+// pokemon is caught
+type Result struct {
+	Caught bool
+	Shakes int
+}
+
+// maxShakes is how many shake checks a throw has to pass, the last one being
+// the catch itself
+const maxShakes int = 4
+
+// catchValue is the modified catch rate of the throw, 255 and above always
+// catches
+func catchValue(target Target, ball Ball) float64 {
+	maxHP, hp := target.MaxHP, target.HP
+	if maxHP <= 0 {
+		maxHP, hp = 1, 1
+	}
+	hp = min(max(hp, 1), maxHP)
+	value := float64(3*maxHP-2*hp) * float64(target.CaptureRate) * ball.modifier() / float64(3*maxHP)
+	return value * target.Status.modifier()
+}
+
+// shakeThreshold is the chance out of 65536 of passing each shake check
+func shakeThreshold(value float64) int {
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/value)))
+}
+
+// Throw throws the ball at the target
+func Throw(rng RNG, target Target, ball Ball) Result {
+	if ball == MasterBall {
+		return Result{Caught: true, Shakes: maxShakes - 1}
+	}
+	value := catchValue(target, ball)
+	if value >= 255 {
+		return Result{Caught: true, Shakes: maxShakes - 1}
+	}
+	if value <= 0 {
+		return Result{}
+	}
+	threshold := shakeThreshold(value)
+	for shakes := 0; shakes < maxShakes; shakes++ {
+		if rng.IntN(65536) >= threshold {
+			return Result{Shakes: shakes}
+		}
+	}
+	return Result{Caught: true, Shakes: maxShakes - 1}
+}
+
+// Chance returns the probability of the ball catching the target, from 0 to 1
+func Chance(target Target, ball Ball) float64 {
+	if ball == MasterBall {
+		return 1
+	}
+	value := catchValue(target, ball)
+	if value >= 255 {
+		return 1
+	}
+	if value <= 0 {
+		return 0
+	}
+	return math.Pow(float64(shakeThreshold(value))/65536, float64(maxShakes))
+}
@@ -0,0 +1,131 @@
+package catch
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// sequenceRNG returns the given numbers in turn, and counts the draws
+type sequenceRNG struct {
+	values []int
+	draws  int
+}
+
+func (r *sequenceRNG) IntN(n int) int {
+	value := r.values[r.draws%len(r.values)]
+	r.draws++
+	return value % n
+}
+
+func TestThrow(t *testing.T) {
+	// capture rate 45 at full HP is a catch value of 15 with a Poke Ball, which
+	// passes a shake check below 32274 out of 65536, and of 30 with an Ultra
+	// Ball, which passes it below 38380
+	fullHP := Target{CaptureRate: 45, MaxHP: 100, HP: 100}
+	tests := []struct {
+		name     string
+		target   Target
+		ball     Ball
+		rolls    []int
+		expected Result
+		draws    int
+	}{
+		{
+			name:     "every shake passes",
+			target:   fullHP,
+			ball:     PokeBall,
+			rolls:    []int{0},
+			expected: Result{Caught: true, Shakes: 3},
+			draws:    4,
+		},
+		{
+			name:     "breaks free on the third shake",
+			target:   fullHP,
+			ball:     PokeBall,
+			rolls:    []int{100, 100, 60000},
+			expected: Result{Shakes: 2},
+			draws:    3,
+		},
+		{
+			name:     "a better ball passes where a poke ball fails",
+			target:   fullHP,
+			ball:     UltraBall,
+			rolls:    []int{35000},
+			expected: Result{Caught: true, Shakes: 3},
+			draws:    4,
+		},
+		{
+			name:     "master ball never fails",
+			target:   Target{CaptureRate: 3, MaxHP: 100, HP: 100},
+			ball:     MasterBall,
+			rolls:    []int{65535},
+			expected: Result{Caught: true, Shakes: 3},
+			draws:    0,
+		},
+		{
+			name:     "weak and asleep target is caught outright",
+			target:   Target{CaptureRate: 255, MaxHP: 100, HP: 1, Status: Asleep},
+			ball:     PokeBall,
+			rolls:    []int{65535},
+			expected: Result{Caught: true, Shakes: 3},
+			draws:    0,
+		},
+		{
+			name:     "zero capture rate never catches",
+			target:   Target{CaptureRate: 0, MaxHP: 100, HP: 100},
+			ball:     UltraBall,
+			rolls:    []int{0},
+			expected: Result{},
+			draws:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := &sequenceRNG{values: tt.rolls}
+			result := Throw(rng, tt.target, tt.ball)
+			if result != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, result)
+			}
+			if rng.draws != tt.draws {
+				t.Errorf("expected %d draws, got %d", tt.draws, rng.draws)
+			}
+		})
+	}
+}
+
+func TestChance(t *testing.T) {
+	fullHP := Target{CaptureRate: 45, MaxHP: 100, HP: 100}
+	pokeBall := Chance(fullHP, PokeBall)
+	if math.Abs(pokeBall-0.0588) > 0.001 {
+		t.Errorf("expected about 5.9%% with a poke ball, got %v", pokeBall)
+	}
+	if ultraBall := Chance(fullHP, UltraBall); ultraBall <= pokeBall {
+		t.Errorf("expected an ultra ball to do better than %v, got %v", pokeBall, ultraBall)
+	}
+	weakened := fullHP
+	weakened.HP = 1
+	if chance := Chance(weakened, PokeBall); chance <= pokeBall {
+		t.Errorf("expected a weakened target to be easier than %v, got %v", pokeBall, chance)
+	}
+	paralyzed := fullHP
+	paralyzed.Status = Paralyzed
+	if chance := Chance(paralyzed, PokeBall); chance <= pokeBall {
+		t.Errorf("expected a paralyzed target to be easier than %v, got %v", pokeBall, chance)
+	}
+	if chance := Chance(fullHP, MasterBall); chance != 1 {
+		t.Errorf("expected a master ball to always catch, got %v", chance)
+	}
+}
+
+func TestParseBall(t *testing.T) {
+	for _, name := range []string{"great", "great-ball", "Great"} {
+		if ball, err := ParseBall(name); err != nil || ball != GreatBall {
+			t.Errorf("%q: expected great-ball, got %q, %v", name, ball, err)
+		}
+	}
+	if _, err := ParseBall("beast"); !errors.Is(err, ErrUnknownBall) {
+		t.Errorf("expected ErrUnknownBall, got %v", err)
+	}
+}
@@ -2,59 +2,319 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/maniac-en/pokefetch/internal/cache"
+	"github.com/maniac-en/pokefetch/internal/ratelimit"
 )
 
 type Client struct {
-	cache      cache.Cache
+	cache      cache.Store
 	httpClient http.Client
+	// baseURL and apiVersion default to the public PokeAPI when left empty
+	baseURL    string
+	apiVersion string
+	// resourceTTLs overrides the cache TTL per resource, e.g. "pokemon"
+	resourceTTLs map[string]time.Duration
+	// staleWhileRevalidate serves expired responses while refreshing them in
+	// the background, revalidating tracks the URLs being refreshed
+	staleWhileRevalidate bool
+	revalidating         sync.Map
+	refreshes            sync.WaitGroup
+	// inflight coalesces the concurrent requests for the same URL
+	inflight    flightGroup
+	retryPolicy RetryPolicy
+	// limiter is nil when the network calls aren't throttled
+	limiter           *ratelimit.Limiter
+	rateLimitCounters rateLimitCounters
 }
 
-func NewClient(timeout, cacheInterval time.Duration) (*Client, error) {
+var ErrInvalidBaseURL = errors.New("invalid base URL")
+
+// Option configures the optional parts of a Client created by NewClient
+type Option func(*options)
+
+type options struct {
+	baseURL              string
+	apiVersion           string
+	cacheDir             string
+	cacheOptions         []cache.Option
+	cache                cache.Store
+	resourceTTLs         map[string]time.Duration
+	staleWhileRevalidate bool
+	retryPolicy          RetryPolicy
+	rateLimit            *rateLimitOptions
+}
+
+// WithBaseURL points the client at another PokeAPI deployment, e.g. a
+// self-hosted mirror or a local stand-in, instead of DefaultBaseURL
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithAPIVersion overrides DefaultAPIVersion, e.g. "/v2"
+func WithAPIVersion(apiVersion string) Option {
+	return func(o *options) {
+		o.apiVersion = apiVersion
+	}
+}
+
+// WithResourceTTL caches the responses of a resource, e.g. "pokemon" or
+// "location-area", for ttl instead of the client's cache interval
+func WithResourceTTL(resource string, ttl time.Duration) Option {
+	return func(o *options) {
+		o.resourceTTLs[resource] = ttl
+	}
+}
+
+// WithStaleWhileRevalidate makes the client answer with an expired cached
+// response right away and refresh it in the background. An injected cache
+// must implement cache.StaleStore and retain the expired entries on its own.
+func WithStaleWhileRevalidate() Option {
+	return func(o *options) {
+		o.staleWhileRevalidate = true
+	}
+}
+
+// WithCache makes the client use the given cache instead of creating its own,
+// it takes precedence over WithCacheDir
+func WithCache(store cache.Store) Option {
+	return func(o *options) {
+		o.cache = store
+	}
+}
+
+// WithCacheDir persists the responses cache under dir, so it survives restarts
+func WithCacheDir(dir string) Option {
+	return func(o *options) {
+		o.cacheDir = dir
+	}
+}
+
+// WithCacheOptions configures the cache created by the client, e.g. its size
+// budget, it has no effect together with WithCache
+func WithCacheOptions(cacheOpts ...cache.Option) Option {
+	return func(o *options) {
+		o.cacheOptions = append(o.cacheOptions, cacheOpts...)
+	}
+}
+
+func NewClient(timeout, cacheInterval time.Duration, opts ...Option) (*Client, error) {
 	if timeout <= 0 {
 		return nil, fmt.Errorf("timeout must be positive")
 	}
 	if cacheInterval <= 0 {
 		return nil, fmt.Errorf("cache interval must be positive")
 	}
+	o := options{
+		baseURL:      DefaultBaseURL,
+		apiVersion:   DefaultAPIVersion,
+		resourceTTLs: maps.Clone(defaultResourceTTLs),
+		retryPolicy:  DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if parsedURL, err := url.Parse(o.baseURL); err != nil ||
+		(parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, fmt.Errorf("%w: must be an absolute http(s) URL, got %q", ErrInvalidBaseURL, o.baseURL)
+	}
+	// expired responses are kept for another interval to be served stale, and
+	// for much longer when they can be revalidated with a conditional request
+	o.cacheOptions = append([]cache.Option{
+		cache.WithStaleRetention(cacheInterval),
+		cache.WithValidatedRetention(validatedRetention),
+	}, o.cacheOptions...)
+
+	limiter, err := newLimiter(o.rateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit: %w", err)
+	}
+
+	var responseCache cache.Store
+	switch {
+	case o.cache != nil:
+		responseCache = o.cache
+	case o.cacheDir != "":
+		diskCache, err := cache.NewDiskCache(cacheInterval, o.cacheDir, o.cacheOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create disk cache: %w", err)
+		}
+		responseCache = diskCache
+	default:
+		responseCache = cache.NewCache(cacheInterval, o.cacheOptions...)
+	}
 	return &Client{
-		cache: cache.NewCache(cacheInterval),
+		cache: responseCache,
 		httpClient: http.Client{
 			Timeout: timeout,
 		},
+		baseURL:              o.baseURL,
+		apiVersion:           o.apiVersion,
+		resourceTTLs:         o.resourceTTLs,
+		staleWhileRevalidate: o.staleWhileRevalidate,
+		retryPolicy:          o.retryPolicy,
+		limiter:              limiter,
 	}, nil
 }
 
+// Close waits for the background refreshes and releases the client's cache,
+// including stopping its reaper, the client must not be used afterwards
+func (client *Client) Close() error {
+	client.refreshes.Wait()
+	return client.cache.Close()
+}
+
+// CacheInspector returns the client's cache when it supports reporting on its
+// content
+func (client *Client) CacheInspector() (cache.Inspector, bool) {
+	inspector, ok := client.cache.(cache.Inspector)
+	return inspector, ok
+}
+
 func (client *Client) GetMapAreas(pageURL *string) (PokeMapAreas, error) {
-	// if the passed URL is empty, i.e., the next/prev URL passed down is empty,
-	// then use the default endpoint which fetches the first page
-	if pageURL != nil {
-		return GetResourceFromPokeAPI[PokeMapAreas](client, pageURL)
-	}
-	defaultURL := mapAreaDefaultEndpoint
-	return GetResourceFromPokeAPI[PokeMapAreas](client, &defaultURL)
+	return client.GetMapAreasContext(context.Background(), pageURL)
+}
+
+func (client *Client) GetMapAreasContext(ctx context.Context, pageURL *string) (PokeMapAreas, error) {
+	return client.getListPage(ctx, mapAreaEndpoint, pageURL)
 }
 
 func (client *Client) GetMapArea(mapAreaName *string) (PokeMapArea, error) {
-	baseURL, _ := url.Parse(mapAreaEndpoint)
-	requestURL := baseURL.JoinPath(*mapAreaName).String()
-	return GetResourceFromPokeAPI[PokeMapArea](client, &requestURL)
+	return client.GetMapAreaContext(context.Background(), mapAreaName)
+}
+
+func (client *Client) GetMapAreaContext(ctx context.Context, mapAreaName *string) (PokeMapArea, error) {
+	requestURL := client.resourceURL(mapAreaEndpoint, *mapAreaName)
+	return GetResourceFromPokeAPIContext[PokeMapArea](ctx, client, &requestURL)
 }
 
 func (client *Client) GetPokemon(pokemonName *string) (Pokemon, error) {
-	baseURL, _ := url.Parse(pokemonEndpoint)
-	requestURL := baseURL.JoinPath(*pokemonName).String()
-	return GetResourceFromPokeAPI[Pokemon](client, &requestURL)
+	return client.GetPokemonContext(context.Background(), pokemonName)
+}
+
+func (client *Client) GetPokemonContext(ctx context.Context, pokemonName *string) (Pokemon, error) {
+	requestURL := client.resourceURL(pokemonEndpoint, *pokemonName)
+	return GetResourceFromPokeAPIContext[Pokemon](ctx, client, &requestURL)
+}
+
+func (client *Client) GetPokemonSpecies(speciesName *string) (PokemonSpecies, error) {
+	return client.GetPokemonSpeciesContext(context.Background(), speciesName)
+}
+
+func (client *Client) GetPokemonSpeciesContext(ctx context.Context, speciesName *string) (PokemonSpecies, error) {
+	requestURL := client.resourceURL(pokemonSpeciesEndpoint, *speciesName)
+	return GetResourceFromPokeAPIContext[PokemonSpecies](ctx, client, &requestURL)
+}
+
+func (client *Client) GetEvolutionChain(chainID *string) (EvolutionChain, error) {
+	return client.GetEvolutionChainContext(context.Background(), chainID)
+}
+
+// GetEvolutionChainContext fetches an evolution chain by id, the chain of a
+// species is found with its EvolutionChain.ID()
+func (client *Client) GetEvolutionChainContext(ctx context.Context, chainID *string) (EvolutionChain, error) {
+	requestURL := client.resourceURL(evolutionChainEndpoint, *chainID)
+	return GetResourceFromPokeAPIContext[EvolutionChain](ctx, client, &requestURL)
+}
+
+func (client *Client) GetType(typeName *string) (Type, error) {
+	return client.GetTypeContext(context.Background(), typeName)
+}
+
+func (client *Client) GetTypeContext(ctx context.Context, typeName *string) (Type, error) {
+	requestURL := client.resourceURL(typeEndpoint, *typeName)
+	return GetResourceFromPokeAPIContext[Type](ctx, client, &requestURL)
+}
+
+func (client *Client) GetMove(moveName *string) (Move, error) {
+	return client.GetMoveContext(context.Background(), moveName)
+}
+
+func (client *Client) GetMoveContext(ctx context.Context, moveName *string) (Move, error) {
+	requestURL := client.resourceURL(moveEndpoint, *moveName)
+	return GetResourceFromPokeAPIContext[Move](ctx, client, &requestURL)
+}
+
+func (client *Client) GetAbility(abilityName *string) (Ability, error) {
+	return client.GetAbilityContext(context.Background(), abilityName)
+}
+
+func (client *Client) GetAbilityContext(ctx context.Context, abilityName *string) (Ability, error) {
+	requestURL := client.resourceURL(abilityEndpoint, *abilityName)
+	return GetResourceFromPokeAPIContext[Ability](ctx, client, &requestURL)
+}
+
+func (client *Client) GetItem(itemName *string) (Item, error) {
+	return client.GetItemContext(context.Background(), itemName)
+}
+
+func (client *Client) GetItemContext(ctx context.Context, itemName *string) (Item, error) {
+	requestURL := client.resourceURL(itemEndpoint, *itemName)
+	return GetResourceFromPokeAPIContext[Item](ctx, client, &requestURL)
+}
+
+func (client *Client) GetBerry(berryName *string) (Berry, error) {
+	return client.GetBerryContext(context.Background(), berryName)
+}
+
+func (client *Client) GetBerryContext(ctx context.Context, berryName *string) (Berry, error) {
+	requestURL := client.resourceURL(berryEndpoint, *berryName)
+	return GetResourceFromPokeAPIContext[Berry](ctx, client, &requestURL)
+}
+
+func (client *Client) GetVersion(versionName *string) (Version, error) {
+	return client.GetVersionContext(context.Background(), versionName)
+}
+
+func (client *Client) GetVersionContext(ctx context.Context, versionName *string) (Version, error) {
+	requestURL := client.resourceURL(versionEndpoint, *versionName)
+	return GetResourceFromPokeAPIContext[Version](ctx, client, &requestURL)
+}
+
+// apiRoot returns the base URL joined with the API version, falling back to
+// the public PokeAPI for the parts left unset
+func (client *Client) apiRoot() string {
+	baseURL, apiVersion := client.baseURL, client.apiVersion
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + strings.Trim(apiVersion, "/")
+}
+
+// endpoint returns the URL of a resource endpoint, e.g. "/pokemon"
+func (client *Client) endpoint(resourceEndpoint string) string {
+	return client.apiRoot() + resourceEndpoint
+}
+
+// resourceURL returns the URL of a single named resource under an endpoint
+func (client *Client) resourceURL(resourceEndpoint, name string) string {
+	endpointURL, _ := url.Parse(client.endpoint(resourceEndpoint))
+	return endpointURL.JoinPath(name).String()
 }
 
 func GetResourceFromPokeAPI[T any](client *Client, URL *string) (T, error) {
+	return GetResourceFromPokeAPIContext[T](context.Background(), client, URL)
+}
+
+// GetResourceFromPokeAPIContext is GetResourceFromPokeAPI with a context, which
+// cancels the request once done. A cancelled request leaves the cache as is.
+func GetResourceFromPokeAPIContext[T any](ctx context.Context, client *Client, URL *string) (T, error) {
 	var zero T
 	if URL == nil {
 		return zero, fmt.Errorf("request URL cannot be empty")
@@ -63,34 +323,186 @@ func GetResourceFromPokeAPI[T any](client *Client, URL *string) (T, error) {
 	var result T
 	if val, ok := client.cache.Get(*URL); ok {
 		if err := json.Unmarshal(val, &result); err != nil {
-			return zero, fmt.Errorf("failed to unmarshal cached data: %w", err)
+			return zero, decodeError(*URL, "cached data", err)
 		}
 		return result, nil
 	}
 
-	req, err := http.NewRequest(http.MethodGet, *URL, nil)
+	if val, ok := client.getStale(*URL); ok {
+		if err := json.Unmarshal(val, &result); err == nil {
+			client.revalidate(*URL)
+			return result, nil
+		}
+	}
+
+	val, err := client.inflight.do(ctx, *URL, func() (any, error) {
+		res, err := client.fetch(ctx, *URL)
+		if err != nil {
+			return nil, err
+		}
+		var decoded T
+		if err := json.Unmarshal(res.data, &decoded); err != nil {
+			return nil, decodeError(*URL, "response", err)
+		}
+		client.store(*URL, res)
+		return sharedResult{decoded: decoded, data: res.data}, nil
+	})
+	if err != nil {
+		return zero, err
+	}
+	shared := val.(sharedResult)
+	if decoded, ok := shared.decoded.(T); ok {
+		return decoded, nil
+	}
+	// the call in flight decoded the same URL into another type
+	if err := json.Unmarshal(shared.data, &result); err != nil {
+		return zero, decodeError(*URL, "response", err)
+	}
+	return result, nil
+}
+
+// sharedResult is what a request in flight hands to every caller waiting on it
+type sharedResult struct {
+	decoded any
+	data    []byte
+}
+
+// response is a downloaded response body along with its HTTP validators
+type response struct {
+	data       []byte
+	validators cache.Validators
+}
+
+// fetch downloads the response of URL, retrying the transient failures as per
+// the client's retry policy. When an expired response with HTTP validators is
+// still cached, the request is made conditional and a 304 Not Modified answer
+// hands back the cached body.
+func (client *Client) fetch(ctx context.Context, URL string) (response, error) {
+	cached, revalidating := client.cachedForRevalidation(URL)
+	return withRetries(ctx, client.retryPolicy, http.MethodGet, func() (response, error) {
+		return client.fetchOnce(ctx, URL, cached, revalidating)
+	})
+}
+
+func (client *Client) fetchOnce(ctx context.Context, URL string, cached response, revalidating bool) (response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, URL, nil)
 	if err != nil {
-		return zero, fmt.Errorf("failed to create request: %w", err)
+		return response{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	if revalidating {
+		if cached.validators.ETag != "" {
+			req.Header.Set("If-None-Match", cached.validators.ETag)
+		}
+		if cached.validators.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.validators.LastModified)
+		}
 	}
 
+	if err := client.waitForRateLimit(ctx); err != nil {
+		return response{}, err
+	}
 	res, err := client.httpClient.Do(req)
 	if err != nil {
-		return zero, fmt.Errorf("failed to execute request: %w", err)
+		return response{}, &transientError{err: requestError(URL, err)}
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotModified && revalidating {
+		return cached, nil
 	}
 	if res.StatusCode != http.StatusOK {
-		if res.StatusCode == http.StatusNotFound {
-			return zero, fmt.Errorf("resource not found at %s", *URL)
+		err := statusError(URL, res.StatusCode)
+		if isTransientStatus(res.StatusCode) {
+			retryAfter := parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+			return response{}, &transientError{err: err, retryAfter: retryAfter}
 		}
-		return zero, fmt.Errorf("received the response with %v status", res.StatusCode)
+		return response{}, err
 	}
-	defer res.Body.Close()
 	data, err := io.ReadAll(res.Body)
 	if err != nil {
-		return zero, fmt.Errorf("failed to read response body: %w", err)
+		return response{}, &transientError{err: &APIError{Kind: ErrNetwork, URL: URL, Err: fmt.Errorf("failed to read response body: %w", err)}}
+	}
+	return response{
+		data: data,
+		validators: cache.Validators{
+			ETag:         res.Header.Get("ETag"),
+			LastModified: res.Header.Get("Last-Modified"),
+		},
+	}, nil
+}
+
+// cachedForRevalidation returns the expired cached response of URL if it has
+// HTTP validators to make a conditional request with
+func (client *Client) cachedForRevalidation(URL string) (response, bool) {
+	validatingCache, ok := client.cache.(cache.ValidatingStore)
+	if !ok {
+		return response{}, false
 	}
-	if err := json.Unmarshal(data, &result); err != nil {
-		return zero, fmt.Errorf("failed to unmarshal response: %w", err)
+	val, validators, ok := validatingCache.GetValidated(URL)
+	if !ok || validators == (cache.Validators{}) {
+		return response{}, false
 	}
-	client.cache.Add(*URL, data)
-	return result, nil
+	return response{data: val, validators: validators}, true
+}
+
+// store caches the response of URL with the TTL of its resource, storing it
+// again after a 304 Not Modified renews its TTL
+func (client *Client) store(URL string, res response) {
+	opts := []cache.EntryOption{cache.WithValidators(res.validators)}
+	if ttl, ok := client.resourceTTLs[client.resourceOf(URL)]; ok {
+		opts = append(opts, cache.WithTTL(ttl))
+	}
+	client.cache.Add(URL, res.data, opts...)
+}
+
+// getStale returns an expired cached response of URL, when stale while
+// revalidate is enabled and the cache keeps expired entries around
+func (client *Client) getStale(URL string) ([]byte, bool) {
+	if !client.staleWhileRevalidate {
+		return nil, false
+	}
+	staleCache, ok := client.cache.(cache.StaleStore)
+	if !ok {
+		return nil, false
+	}
+	val, _, ok := staleCache.GetStale(URL)
+	return val, ok
+}
+
+// revalidate refreshes the cached response of URL in the background, at most
+// once at a time per URL
+func (client *Client) revalidate(URL string) {
+	if _, loaded := client.revalidating.LoadOrStore(URL, struct{}{}); loaded {
+		return
+	}
+	client.refreshes.Add(1)
+	go func() {
+		defer client.refreshes.Done()
+		defer client.revalidating.Delete(URL)
+		// the refresh outlives the call which triggered it
+		res, err := client.fetch(context.Background(), URL)
+		if err != nil {
+			// the stale value keeps being served until a refresh succeeds
+			return
+		}
+		client.store(URL, res)
+	}()
+}
+
+// resourceOf returns the resource name of a PokeAPI URL, e.g. "pokemon" for
+// https://pokeapi.co/api/v2/pokemon/pikachu
+func (client *Client) resourceOf(URL string) string {
+	parsedURL, err := url.Parse(URL)
+	if err != nil {
+		return ""
+	}
+	rootURL, err := url.Parse(client.apiRoot())
+	if err != nil {
+		return ""
+	}
+	path, ok := strings.CutPrefix(parsedURL.Path, rootURL.Path+"/")
+	if !ok {
+		return ""
+	}
+	resource, _, _ := strings.Cut(path, "/")
+	return resource
 }
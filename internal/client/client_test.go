@@ -1,11 +1,17 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -106,6 +112,551 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_WithCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	url := "https://pokeapi.co/api/v2/pokemon/pikachu"
+
+	first, err := NewClient(5*time.Second, time.Minute, WithCacheDir(dir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.cache.Add(url, []byte(`{"id": 25, "name": "pikachu"}`))
+
+	second, err := NewClient(5*time.Second, time.Minute, WithCacheDir(dir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second.httpClient.Transport = mockTransport(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("HTTP request should not be made when the disk cache hits")
+		return nil, nil
+	})
+
+	result, err := GetResourceFromPokeAPI[Pokemon](second, &url)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ID != 25 {
+		t.Errorf("expected ID 25, got %d", result.ID)
+	}
+}
+
+func TestNewClient_WithCache(t *testing.T) {
+	url := "https://pokeapi.co/api/v2/pokemon/pikachu"
+	requests := 0
+
+	client, err := NewClient(5*time.Second, time.Minute, WithCache(cache.Nop{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.httpClient.Transport = mockTransport(func(req *http.Request) (*http.Response, error) {
+		requests++
+		return createResponse(http.StatusOK, `{"id": 25, "name": "pikachu"}`, map[string]string{}), nil
+	})
+
+	for range 2 {
+		if _, err := GetResourceFromPokeAPI[Pokemon](client, &url); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected every call to reach the network with a no-op cache, got %d requests", requests)
+	}
+}
+
+func TestClient_CloseLeaksNoGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for range 10 {
+		client, err := NewClient(5*time.Second, time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := client.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected %d goroutines after close, got %d", before, after)
+	}
+}
+
+func TestNewClient_WithResourceTTL(t *testing.T) {
+	expiryTime := 5 * time.Millisecond
+	pokemonURL := "https://pokeapi.co/api/v2/pokemon/pikachu"
+	mapAreaURL := "https://pokeapi.co/api/v2/location-area/canalave-city-area"
+
+	client, err := NewClient(5*time.Second, expiryTime, WithResourceTTL("pokemon", time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+	client.httpClient.Transport = mockTransport(func(req *http.Request) (*http.Response, error) {
+		return createResponse(http.StatusOK, `{"id": 1, "name": "test"}`, map[string]string{}), nil
+	})
+
+	if _, err := GetResourceFromPokeAPI[Pokemon](client, &pokemonURL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := GetResourceFromPokeAPI[PokeMapArea](client, &mapAreaURL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(expiryTime * 2)
+
+	if _, ok := client.cache.Get(pokemonURL); !ok {
+		t.Errorf("expected %s to be kept for the pokemon TTL", pokemonURL)
+	}
+	if _, ok := client.cache.Get(mapAreaURL); ok {
+		t.Errorf("expected %s to expire with the cache interval", mapAreaURL)
+	}
+}
+
+func TestGetResourceFromPokeAPI_StaleWhileRevalidate(t *testing.T) {
+	expiryTime := 20 * time.Millisecond
+	url := "https://pokeapi.co/api/v2/location-area/canalave-city-area"
+	responses := []string{`{"id": 1, "name": "old"}`, `{"id": 1, "name": "new"}`}
+	requests := 0
+
+	// the expired entry is retained well past the sleep below, so scheduling
+	// delays can't purge it before it's served stale
+	client, err := NewClient(5*time.Second, expiryTime, WithStaleWhileRevalidate(),
+		WithCacheOptions(cache.WithStaleRetention(time.Minute)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+	client.httpClient.Transport = mockTransport(func(req *http.Request) (*http.Response, error) {
+		body := responses[min(requests, len(responses)-1)]
+		requests++
+		return createResponse(http.StatusOK, body, map[string]string{}), nil
+	})
+
+	if _, err := GetResourceFromPokeAPI[PokeMapArea](client, &url); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(expiryTime + expiryTime/2)
+
+	result, err := GetResourceFromPokeAPI[PokeMapArea](client, &url)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "old" {
+		t.Errorf("expected the stale value to be served, got %s", result.Name)
+	}
+
+	client.refreshes.Wait()
+	if requests != 2 {
+		t.Errorf("expected a single background refresh, got %d requests", requests)
+	}
+	result, err = GetResourceFromPokeAPI[PokeMapArea](client, &url)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "new" {
+		t.Errorf("expected the refreshed value, got %s", result.Name)
+	}
+}
+
+func TestGetResourceFromPokeAPI_ConditionalRevalidation(t *testing.T) {
+	tests := []struct {
+		name            string
+		validatorHeader string
+		validatorValue  string
+		requestHeader   string
+	}{
+		{
+			name:            "ETag",
+			validatorHeader: "ETag",
+			validatorValue:  `"v1"`,
+			requestHeader:   "If-None-Match",
+		},
+		{
+			name:            "Last-Modified",
+			validatorHeader: "Last-Modified",
+			validatorValue:  "Wed, 21 Oct 2015 07:28:00 GMT",
+			requestHeader:   "If-Modified-Since",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiryTime := 20 * time.Millisecond
+			fullResponses, notModifiedResponses := 0, 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(tt.requestHeader) == tt.validatorValue {
+					notModifiedResponses++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				fullResponses++
+				w.Header().Set(tt.validatorHeader, tt.validatorValue)
+				fmt.Fprint(w, `{"id": 1, "name": "canalave-city-area"}`)
+			}))
+			defer server.Close()
+			url := server.URL + "/api/v2/location-area/canalave-city-area"
+
+			// the expired entry and its validators are retained well past the
+			// sleep below, so scheduling delays can't purge them
+			client, err := NewClient(5*time.Second, expiryTime,
+				WithCacheOptions(cache.WithStaleRetention(time.Minute)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer client.Close()
+
+			if _, err := GetResourceFromPokeAPI[PokeMapArea](client, &url); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			time.Sleep(expiryTime + expiryTime/2)
+			if _, ok := client.cache.Get(url); ok {
+				t.Fatalf("expected the cached response to have expired")
+			}
+
+			result, err := GetResourceFromPokeAPI[PokeMapArea](client, &url)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Name != "canalave-city-area" {
+				t.Errorf("expected the cached body to be reused, got %+v", result)
+			}
+			if fullResponses != 1 || notModifiedResponses != 1 {
+				t.Errorf("expected 1 full and 1 not modified response, got %d and %d",
+					fullResponses, notModifiedResponses)
+			}
+			if _, ok := client.cache.Get(url); !ok {
+				t.Errorf("expected the 304 to renew the cached response")
+			}
+		})
+	}
+}
+
+func TestGetResourceFromPokeAPI_RevalidatesPastStaleRetention(t *testing.T) {
+	expiryTime := 5 * time.Millisecond
+	fullResponses, notModifiedResponses := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModifiedResponses++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses++
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id": 1, "name": "canalave-city-area"}`)
+	}))
+	defer server.Close()
+	url := server.URL + "/api/v2/location-area/canalave-city-area"
+
+	client, err := NewClient(5*time.Second, expiryTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := GetResourceFromPokeAPI[PokeMapArea](client, &url); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// well past the expiry and the stale retention of one cache interval, the
+	// reaper has run a few times meanwhile
+	time.Sleep(expiryTime * 10)
+
+	if _, err := GetResourceFromPokeAPI[PokeMapArea](client, &url); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fullResponses != 1 || notModifiedResponses != 1 {
+		t.Errorf("expected 1 full and 1 not modified response, got %d and %d",
+			fullResponses, notModifiedResponses)
+	}
+}
+
+func TestGetResourceFromPokeAPI_CoalescesConcurrentRequests(t *testing.T) {
+	const callers = 10
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		fmt.Fprint(w, `{"id": 25, "name": "pikachu"}`)
+	}))
+	defer server.Close()
+	url := server.URL + "/api/v2/pokemon/pikachu"
+
+	client, err := NewClient(5*time.Second, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	results := make([]Pokemon, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = GetResourceFromPokeAPI[Pokemon](client, &url)
+		}()
+	}
+
+	// hold the response until every other caller joined the first one
+	for {
+		client.inflight.mu.Lock()
+		call, ok := client.inflight.calls[url]
+		joined := ok && call.dups == callers-1
+		client.inflight.mu.Unlock()
+		if joined {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if requests.Load() != 1 {
+		t.Errorf("expected a single request, got %d", requests.Load())
+	}
+	for i := range callers {
+		if errs[i] != nil {
+			t.Errorf("caller %d: unexpected error: %v", i, errs[i])
+		}
+		if results[i].ID != 25 {
+			t.Errorf("caller %d: expected ID 25, got %d", i, results[i].ID)
+		}
+	}
+}
+
+func TestGetResourceFromPokeAPIContext_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	url := server.URL + "/api/v2/pokemon/pikachu"
+
+	client, err := NewClient(5*time.Second, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := GetResourceFromPokeAPIContext[Pokemon](ctx, client, &url)
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the request to return once cancelled")
+	}
+	if _, ok := client.cache.Get(url); ok {
+		t.Errorf("expected nothing to be cached for a cancelled request")
+	}
+}
+
+func TestFlightGroup_WaiterRetriesAfterCancelledCall(t *testing.T) {
+	var group flightGroup
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := group.do(leaderCtx, "key", func() (any, error) {
+			close(started)
+			<-leaderCtx.Done()
+			return nil, leaderCtx.Err()
+		})
+		leaderErr <- err
+	}()
+	<-started
+
+	waiterVal := make(chan any, 1)
+	go func() {
+		val, _ := group.do(context.Background(), "key", func() (any, error) {
+			return "fresh", nil
+		})
+		waiterVal <- val
+	}()
+	for {
+		group.mu.Lock()
+		joined := group.calls["key"].dups == 1
+		group.mu.Unlock()
+		if joined {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancelLeader()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the leader to be cancelled, got %v", err)
+	}
+	if val := <-waiterVal; val != "fresh" {
+		t.Errorf("expected the waiter to make the call itself, got %v", val)
+	}
+}
+
+func TestNewClient_WithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "test"}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(5*time.Second, time.Minute, WithRateLimit(50, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	for _, name := range []string{"bulbasaur", "ivysaur", "venusaur", "bulbasaur"} {
+		url := server.URL + "/api/v2/pokemon/" + name
+		if _, err := GetResourceFromPokeAPI[Pokemon](client, &url); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	stats := client.RateLimitStats()
+	if stats.Throttled != 2 {
+		t.Errorf("expected 2 throttled calls, the cache hit being exempt, got %d", stats.Throttled)
+	}
+	if stats.TotalWait < 20*time.Millisecond {
+		t.Errorf("expected about 40ms of total wait, got %v", stats.TotalWait)
+	}
+}
+
+func TestWithRateLimitWait(t *testing.T) {
+	const callers = 4
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "test"}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(5*time.Second, time.Minute, WithRateLimit(50, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	waits := make([][]time.Duration, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := WithRateLimitWait(context.Background(), func(waited time.Duration) {
+				waits[i] = append(waits[i], waited)
+			})
+			url := fmt.Sprintf("%s/api/v2/pokemon/%d", server.URL, i)
+			if _, err := GetResourceFromPokeAPIContext[Pokemon](ctx, client, &url); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			// a cache hit makes no request, so it doesn't report
+			if _, err := GetResourceFromPokeAPIContext[Pokemon](ctx, client, &url); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var total time.Duration
+	for i, callerWaits := range waits {
+		if len(callerWaits) != 1 {
+			t.Fatalf("expected caller %d to be told about its single request, got %v", i, callerWaits)
+		}
+		total += callerWaits[0]
+	}
+	if stats := client.RateLimitStats(); total != stats.TotalWait {
+		t.Errorf("expected the callers' waits to add up to the total wait %v, got %v", stats.TotalWait, total)
+	}
+}
+
+func TestNewClient_InvalidRateLimit(t *testing.T) {
+	client, err := NewClient(5*time.Second, time.Minute, WithRateLimit(0, 1))
+	if err == nil || !strings.Contains(err.Error(), "rate must be positive") {
+		t.Errorf("expected an invalid rate error, got %v", err)
+	}
+	if client != nil {
+		t.Errorf("expected nil client on error, got %v", client)
+	}
+}
+
+func TestResourceOf(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://pokeapi.co/api/v2/pokemon/pikachu", "pokemon"},
+		{"https://pokeapi.co/api/v2/location-area?offset=0&limit=20", "location-area"},
+		{"https://pokeapi.co/api/v2/", ""},
+		{"https://example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if actual := (&Client{}).resourceOf(tt.url); actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewClient_WithBaseURL(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		fmt.Fprint(w, `{"id": 25, "name": "pikachu"}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(5*time.Second, time.Minute,
+		WithBaseURL(server.URL+"/mirror/"), WithAPIVersion("v3"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	pokemonName, mapAreaName := "pikachu", "canalave-city-area"
+	if _, err := client.GetPokemon(&pokemonName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetMapArea(&mapAreaName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetMapAreas(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedPaths := []string{
+		"/mirror/v3/pokemon/pikachu",
+		"/mirror/v3/location-area/canalave-city-area",
+		"/mirror/v3/location-area?offset=0&limit=20",
+	}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("expected requests to %v, got %v", expectedPaths, paths)
+	}
+	if resource := client.resourceOf(server.URL + "/mirror/v3/pokemon/pikachu"); resource != "pokemon" {
+		t.Errorf("expected resource pokemon, got %q", resource)
+	}
+}
+
+func TestNewClient_InvalidBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"", "pokeapi.co/api", "ftp://pokeapi.co/api", "https://"} {
+		client, err := NewClient(5*time.Second, time.Minute, WithBaseURL(baseURL))
+		if !errors.Is(err, ErrInvalidBaseURL) {
+			t.Errorf("expected ErrInvalidBaseURL for base URL %q, got %v", baseURL, err)
+		}
+		if client != nil {
+			t.Errorf("expected nil client on error, got %v", client)
+		}
+	}
+}
+
 func TestGetMapAreas_Success(t *testing.T) {
 	mockResponse := `{
 		"count": 781,
@@ -298,6 +849,34 @@ func TestGetPokemon_Success(t *testing.T) {
 	}
 }
 
+func TestGetVersion_Success(t *testing.T) {
+	versionName := "red"
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/version/red"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %s, got %s", expectedURL, req.URL.String())
+				}
+				return createResponse(http.StatusOK, `{
+					"id": 1,
+					"name": "red",
+					"version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}
+				}`, map[string]string{}), nil
+			}),
+		},
+	}
+
+	version, err := client.GetVersion(&versionName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version.VersionGroup.Name != "red-blue" {
+		t.Errorf("expected version group red-blue, got %s", version.VersionGroup.Name)
+	}
+}
+
 func TestGetResourceFromPokeAPI_ErrorCases(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -376,6 +955,94 @@ func TestGetResourceFromPokeAPI_ErrorCases(t *testing.T) {
 	}
 }
 
+func TestGetResourceFromPokeAPI_TypedErrors(t *testing.T) {
+	url := "https://pokeapi.co/api/v2/pokemon/pikachu"
+	tests := []struct {
+		name       string
+		transport  mockTransport
+		kind       error
+		statusCode int
+	}{
+		{
+			name: "not found",
+			transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return createResponse(http.StatusNotFound, "", map[string]string{}), nil
+			}),
+			kind:       ErrNotFound,
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name: "rate limited",
+			transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return createResponse(http.StatusTooManyRequests, "", map[string]string{}), nil
+			}),
+			kind:       ErrRateLimited,
+			statusCode: http.StatusTooManyRequests,
+		},
+		{
+			name: "server error",
+			transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return createResponse(http.StatusBadGateway, "", map[string]string{}), nil
+			}),
+			kind:       ErrServer,
+			statusCode: http.StatusBadGateway,
+		},
+		{
+			name: "unexpected status",
+			transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return createResponse(http.StatusForbidden, "", map[string]string{}), nil
+			}),
+			kind:       ErrUnexpectedStatus,
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name: "decode",
+			transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return createResponse(http.StatusOK, "invalid json", map[string]string{}), nil
+			}),
+			kind: ErrDecode,
+		},
+		{
+			name: "timeout",
+			transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return nil, context.DeadlineExceeded
+			}),
+			kind: ErrTimeout,
+		},
+		{
+			name: "network",
+			transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return nil, fmt.Errorf("connection refused")
+			}),
+			kind: ErrNetwork,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				cache:      cache.NewCache(3 * time.Second),
+				httpClient: http.Client{Transport: tt.transport},
+			}
+
+			_, err := GetResourceFromPokeAPI[Pokemon](client, &url)
+			if !errors.Is(err, tt.kind) {
+				t.Fatalf("expected error matching %v, got %v", tt.kind, err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an *APIError, got %T", err)
+			}
+			if apiErr.URL != url {
+				t.Errorf("expected URL %s, got %s", url, apiErr.URL)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("expected status %d, got %d", tt.statusCode, apiErr.StatusCode)
+			}
+		})
+	}
+}
+
 func TestGetResourceFromPokeAPI_CacheHit(t *testing.T) {
 	url := "https://pokeapi.co/api/v2/pokemon/pikachu"
 	cachedData := []byte(`{"id": 25, "name": "pikachu", "base_experience": 112}`)
@@ -1,10 +1,41 @@
 package client
 
+import "time"
+
 const (
-	LIMIT                  string = "20"
-	baseURL                string = "https://pokeapi.co/api"
-	apiVersion             string = "/v2"
-	mapAreaEndpoint        string = baseURL + apiVersion + "/location-area"
-	mapAreaDefaultEndpoint string = mapAreaEndpoint + "?offset=0&limit=20"
-	pokemonEndpoint        string = baseURL + apiVersion + "/pokemon"
+	LIMIT string = "20"
+	// DefaultBaseURL and DefaultAPIVersion point at the public PokeAPI, both can
+	// be overridden with WithBaseURL and WithAPIVersion
+	DefaultBaseURL         string = "https://pokeapi.co/api"
+	DefaultAPIVersion      string = "/v2"
+	mapAreaEndpoint        string = "/location-area"
+	firstPageQuery         string = "?offset=0&limit=" + LIMIT
+	pokemonEndpoint        string = "/pokemon"
+	pokemonSpeciesEndpoint string = "/pokemon-species"
+	evolutionChainEndpoint string = "/evolution-chain"
+	typeEndpoint           string = "/type"
+	moveEndpoint           string = "/move"
+	abilityEndpoint        string = "/ability"
+	itemEndpoint           string = "/item"
+	berryEndpoint          string = "/berry"
+	versionEndpoint        string = "/version"
 )
+
+// validatedRetention keeps the expired responses with HTTP validators, so
+// they're revalidated with a conditional request instead of downloaded again
+// however long after expiring they're needed
+const validatedRetention time.Duration = 30 * 24 * time.Hour
+
+// defaultResourceTTLs caches the resources which basically never change for
+// longer than the client's cache interval
+var defaultResourceTTLs = map[string]time.Duration{
+	"pokemon":         24 * time.Hour,
+	"pokemon-species": 24 * time.Hour,
+	"evolution-chain": 24 * time.Hour,
+	"type":            24 * time.Hour,
+	"move":            24 * time.Hour,
+	"ability":         24 * time.Hour,
+	"item":            24 * time.Hour,
+	"berry":           24 * time.Hour,
+	"version":         24 * time.Hour,
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// The kinds of failure a PokeAPI call can end with, every error returned by
+// the client for a failed call matches one of them with errors.Is
+var (
+	ErrNotFound    = errors.New("resource not found")
+	ErrRateLimited = errors.New("rate limited by the server")
+	ErrServer      = errors.New("server error")
+	// ErrUnexpectedStatus is any other non 200 response status
+	ErrUnexpectedStatus = errors.New("unexpected response status")
+	ErrDecode           = errors.New("failed to decode response")
+	ErrTimeout          = errors.New("request timed out")
+	ErrNetwork          = errors.New("network error")
+)
+
+// APIError describes a failed PokeAPI call, use errors.As to get at the URL
+// and the response status of the failure
+type APIError struct {
+	// Kind is one of the Err* sentinels above
+	Kind error
+	URL  string
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	// Err is the underlying cause, if any
+	Err error
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Kind == ErrNotFound:
+		return fmt.Sprintf("resource not found at %s", e.URL)
+	case e.Err == nil && e.StatusCode != 0:
+		return fmt.Sprintf("received the response with %v status", e.StatusCode)
+	case e.Err == nil:
+		return fmt.Sprintf("%v: %s", e.Kind, e.URL)
+	default:
+		return e.Err.Error()
+	}
+}
+
+func (e *APIError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// statusError returns the error for a non 200 response status
+func statusError(URL string, statusCode int) *APIError {
+	var kind error
+	switch {
+	case statusCode == http.StatusNotFound:
+		kind = ErrNotFound
+	case statusCode == http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case statusCode >= http.StatusInternalServerError:
+		kind = ErrServer
+	default:
+		kind = ErrUnexpectedStatus
+	}
+	return &APIError{Kind: kind, URL: URL, StatusCode: statusCode}
+}
+
+// requestError returns the error for a request which got no response, it still
+// matches context.Canceled and the like through its cause
+func requestError(URL string, err error) *APIError {
+	kind := ErrNetwork
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		kind = ErrTimeout
+	}
+	return &APIError{Kind: kind, URL: URL, Err: fmt.Errorf("failed to execute request: %w", err)}
+}
+
+// decodeError returns the error for a body which doesn't decode, what tells
+// where the body came from, e.g. "response" or "cached data"
+func decodeError(URL, what string, err error) *APIError {
+	return &APIError{Kind: ErrDecode, URL: URL, Err: fmt.Errorf("failed to unmarshal %s: %w", what, err)}
+}
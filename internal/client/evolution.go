@@ -0,0 +1,110 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ID returns the id PokeAPI gives the resource in its URL, e.g. "10" for
+// https://pokeapi.co/api/v2/evolution-chain/10/
+func (resource APIResource) ID() (string, bool) {
+	parsedURL, err := url.Parse(resource.URL)
+	if err != nil {
+		return "", false
+	}
+	id := path.Base(strings.TrimSuffix(parsedURL.Path, "/"))
+	if id == "." || id == "/" {
+		return "", false
+	}
+	return id, true
+}
+
+// String describes the evolution in a few words, e.g. "level 16, during day"
+func (detail EvolutionDetail) String() string {
+	var conditions []string
+	switch trigger := detail.Trigger.Name; {
+	case trigger == "level-up" && detail.MinLevel != nil:
+		conditions = append(conditions, fmt.Sprintf("level %d", *detail.MinLevel))
+	case trigger == "use-item" && detail.Item != nil:
+		conditions = append(conditions, "use "+detail.Item.Name)
+	default:
+		conditions = append(conditions, strings.ReplaceAll(trigger, "-", " "))
+	}
+	if detail.Trigger.Name != "use-item" && detail.Item != nil {
+		conditions = append(conditions, "with "+detail.Item.Name)
+	}
+	if detail.HeldItem != nil {
+		conditions = append(conditions, "holding "+detail.HeldItem.Name)
+	}
+	if detail.KnownMove != nil {
+		conditions = append(conditions, "knowing "+detail.KnownMove.Name)
+	}
+	if detail.KnownMoveType != nil {
+		conditions = append(conditions, fmt.Sprintf("knowing a %s move", detail.KnownMoveType.Name))
+	}
+	if detail.Location != nil {
+		conditions = append(conditions, "at "+detail.Location.Name)
+	}
+	if detail.MinHappiness != nil {
+		conditions = append(conditions, fmt.Sprintf("happiness %d+", *detail.MinHappiness))
+	}
+	if detail.MinBeauty != nil {
+		conditions = append(conditions, fmt.Sprintf("beauty %d+", *detail.MinBeauty))
+	}
+	if detail.MinAffection != nil {
+		conditions = append(conditions, fmt.Sprintf("affection %d+", *detail.MinAffection))
+	}
+	if detail.TimeOfDay != "" {
+		conditions = append(conditions, "during "+detail.TimeOfDay)
+	}
+	if detail.NeedsOverworldRain {
+		conditions = append(conditions, "while raining")
+	}
+	if detail.PartySpecies != nil {
+		conditions = append(conditions, fmt.Sprintf("with %s in the party", detail.PartySpecies.Name))
+	}
+	if detail.PartyType != nil {
+		conditions = append(conditions, fmt.Sprintf("with a %s pokemon in the party", detail.PartyType.Name))
+	}
+	if detail.RelativePhysicalStats != nil {
+		switch *detail.RelativePhysicalStats {
+		case 1:
+			conditions = append(conditions, "attack > defense")
+		case 0:
+			conditions = append(conditions, "attack = defense")
+		case -1:
+			conditions = append(conditions, "attack < defense")
+		}
+	}
+	if detail.TradeSpecies != nil {
+		conditions = append(conditions, "for "+detail.TradeSpecies.Name)
+	}
+	if detail.Gender != nil {
+		// PokeAPI numbers the genders 1 for female and 2 for male
+		switch *detail.Gender {
+		case 1:
+			conditions = append(conditions, "female only")
+		case 2:
+			conditions = append(conditions, "male only")
+		}
+	}
+	if detail.TurnUpsideDown {
+		conditions = append(conditions, "with the console upside down")
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// Find returns the link of the given species in the chain, if any
+func (link ChainLink) Find(speciesName string) (ChainLink, bool) {
+	if link.Species.Name == speciesName {
+		return link, true
+	}
+	for _, next := range link.EvolvesTo {
+		if found, ok := next.Find(speciesName); ok {
+			return found, true
+		}
+	}
+	return ChainLink{}, false
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/maniac-en/pokefetch/internal/cache"
+)
+
+func TestGetEvolutionChain_Branches(t *testing.T) {
+	chainID := "67"
+	mockResponse := `{
+		"id": 67,
+		"baby_trigger_item": null,
+		"chain": {
+			"is_baby": false,
+			"species": {"name": "eevee", "url": "https://pokeapi.co/api/v2/pokemon-species/133/"},
+			"evolution_details": [],
+			"evolves_to": [
+				{
+					"is_baby": false,
+					"species": {"name": "vaporeon", "url": "https://pokeapi.co/api/v2/pokemon-species/134/"},
+					"evolution_details": [
+						{
+							"trigger": {"name": "use-item", "url": "https://pokeapi.co/api/v2/evolution-trigger/3/"},
+							"item": {"name": "water-stone", "url": "https://pokeapi.co/api/v2/item/84/"}
+						}
+					],
+					"evolves_to": []
+				},
+				{
+					"is_baby": false,
+					"species": {"name": "espeon", "url": "https://pokeapi.co/api/v2/pokemon-species/196/"},
+					"evolution_details": [
+						{
+							"trigger": {"name": "level-up", "url": "https://pokeapi.co/api/v2/evolution-trigger/1/"},
+							"min_happiness": 160,
+							"time_of_day": "day"
+						}
+					],
+					"evolves_to": []
+				}
+			]
+		}
+	}`
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/evolution-chain/67"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %s, got %s", expectedURL, req.URL.String())
+				}
+				return createResponse(http.StatusOK, mockResponse, map[string]string{}), nil
+			}),
+		},
+	}
+
+	chain, err := client.GetEvolutionChain(&chainID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if chain.Chain.Species.Name != "eevee" {
+		t.Fatalf("expected the chain to start at eevee, got %s", chain.Chain.Species.Name)
+	}
+	if len(chain.Chain.EvolvesTo) != 2 {
+		t.Fatalf("expected 2 branches, got %d", len(chain.Chain.EvolvesTo))
+	}
+	if got := chain.Chain.EvolvesTo[0].EvolutionDetails[0].String(); got != "use water-stone" {
+		t.Errorf("expected 'use water-stone', got %q", got)
+	}
+	if got := chain.Chain.EvolvesTo[1].EvolutionDetails[0].String(); got != "level up, happiness 160+, during day" {
+		t.Errorf("expected 'level up, happiness 160+, during day', got %q", got)
+	}
+}
+
+func TestEvolutionDetail_String(t *testing.T) {
+	level := 16
+	tests := []struct {
+		name     string
+		detail   EvolutionDetail
+		expected string
+	}{
+		{
+			name: "level",
+			detail: EvolutionDetail{
+				Trigger:  NamedAPIResource{Name: "level-up"},
+				MinLevel: &level,
+			},
+			expected: "level 16",
+		},
+		{
+			name: "trade holding an item",
+			detail: EvolutionDetail{
+				Trigger:  NamedAPIResource{Name: "trade"},
+				HeldItem: &NamedAPIResource{Name: "metal-coat"},
+			},
+			expected: "trade, holding metal-coat",
+		},
+		{
+			name:     "other trigger",
+			detail:   EvolutionDetail{Trigger: NamedAPIResource{Name: "three-critical-hits"}},
+			expected: "three critical hits",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.detail.String(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAPIResource_ID(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+		ok       bool
+	}{
+		{url: "https://pokeapi.co/api/v2/evolution-chain/10/", expected: "10", ok: true},
+		{url: "https://pokeapi.co/api/v2/evolution-chain/10", expected: "10", ok: true},
+		{url: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		id, ok := APIResource{URL: tt.url}.ID()
+		if id != tt.expected || ok != tt.ok {
+			t.Errorf("%q: expected (%q, %v), got (%q, %v)", tt.url, tt.expected, tt.ok, id, ok)
+		}
+	}
+}
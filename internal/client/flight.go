@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// flightGroup coalesces concurrent calls for the same key, so only the first
+// one does the work and every other caller waits for and shares its result
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	val  any
+	err  error
+	// dups counts the callers which joined this call instead of making it
+	dups int
+}
+
+// do runs fn for key unless a call for key is already in flight, in which case
+// it waits for that call and returns its result. A waiter stops waiting once
+// its own ctx is done, and makes the call itself when the one it waited for
+// was cancelled by its caller.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (any, error)) (any, error) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[string]*flightCall)
+		}
+		call, ok := g.calls[key]
+		if !ok {
+			break
+		}
+		call.dups++
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if isContextErr(call.err) && ctx.Err() == nil {
+			continue
+		}
+		return call.val, call.err
+	}
+
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.val, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.val, call.err
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,6 @@
+package client
+
+// Effect returns the short effect of the item in the given language
+func (item Item) Effect(language string) (string, bool) {
+	return shortEffect(item.EffectEntries, language)
+}
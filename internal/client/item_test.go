@@ -0,0 +1,86 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/maniac-en/pokefetch/internal/cache"
+)
+
+func TestGetItem_Success(t *testing.T) {
+	itemName := "thunder-stone"
+	mockResponse := `{
+		"id": 83,
+		"name": "thunder-stone",
+		"cost": 3000,
+		"category": {"name": "evolution", "url": "https://pokeapi.co/api/v2/item-category/10/"},
+		"effect_entries": [
+			{
+				"effect": "Used on a party Pokémon: Evolves a Pikachu into Raichu.",
+				"short_effect": "Evolves a Pikachu into Raichu.",
+				"language": {"name": "en", "url": "https://pokeapi.co/api/v2/language/9/"}
+			}
+		]
+	}`
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/item/thunder-stone"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %s, got %s", expectedURL, req.URL.String())
+				}
+				return createResponse(http.StatusOK, mockResponse, map[string]string{}), nil
+			}),
+		},
+	}
+
+	item, err := client.GetItem(&itemName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Category.Name != "evolution" || item.Cost != 3000 {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if effect, ok := item.Effect("en"); !ok || effect != "Evolves a Pikachu into Raichu." {
+		t.Errorf("unexpected effect %q", effect)
+	}
+}
+
+func TestGetBerry_Success(t *testing.T) {
+	berryName := "cheri"
+	mockResponse := `{
+		"id": 1,
+		"name": "cheri",
+		"growth_time": 3,
+		"natural_gift_power": 60,
+		"firmness": {"name": "soft", "url": "https://pokeapi.co/api/v2/berry-firmness/2/"},
+		"flavors": [{"potency": 10, "flavor": {"name": "spicy", "url": "https://pokeapi.co/api/v2/berry-flavor/1/"}}],
+		"item": {"name": "cheri-berry", "url": "https://pokeapi.co/api/v2/item/126/"},
+		"natural_gift_type": {"name": "fire", "url": "https://pokeapi.co/api/v2/type/10/"}
+	}`
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/berry/cheri"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %s, got %s", expectedURL, req.URL.String())
+				}
+				return createResponse(http.StatusOK, mockResponse, map[string]string{}), nil
+			}),
+		},
+	}
+
+	berry, err := client.GetBerry(&berryName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if berry.Item.Name != "cheri-berry" || berry.Firmness.Name != "soft" {
+		t.Errorf("unexpected berry %+v", berry)
+	}
+	if len(berry.Flavors) != 1 || berry.Flavors[0].Potency != 10 {
+		t.Errorf("unexpected flavors %+v", berry.Flavors)
+	}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// GetList fetches a page of a list endpoint, e.g. "pokemon" or "move", pageURL
+// is the Next or Previous URL of another page, nil for the first page
+func (client *Client) GetList(resource string, pageURL *string) (NamedResourceList, error) {
+	return client.GetListContext(context.Background(), resource, pageURL)
+}
+
+func (client *Client) GetListContext(ctx context.Context, resource string, pageURL *string) (NamedResourceList, error) {
+	if resource == "" || strings.Contains(resource, "/") {
+		return NamedResourceList{}, fmt.Errorf("invalid resource %q", resource)
+	}
+	return client.getListPage(ctx, "/"+resource, pageURL)
+}
+
+func (client *Client) getListPage(ctx context.Context, resourceEndpoint string, pageURL *string) (NamedResourceList, error) {
+	// if the passed URL is empty, i.e., the next/prev URL passed down is empty,
+	// then use the default endpoint which fetches the first page
+	if pageURL != nil {
+		return GetResourceFromPokeAPIContext[NamedResourceList](ctx, client, pageURL)
+	}
+	defaultURL := client.endpoint(resourceEndpoint) + firstPageQuery
+	return GetResourceFromPokeAPIContext[NamedResourceList](ctx, client, &defaultURL)
+}
+
+// List iterates over every resource of a list endpoint, e.g. "pokemon" or
+// "move". The pages are fetched through the cache as the iteration reaches
+// them, so stopping early never fetches the rest. A failure to fetch a page is
+// yielded once along with a zero NamedAPIResource, and ends the iteration.
+func (client *Client) List(ctx context.Context, resource string) iter.Seq2[NamedAPIResource, error] {
+	return func(yield func(NamedAPIResource, error) bool) {
+		var pageURL *string
+		for {
+			page, err := client.GetListContext(ctx, resource, pageURL)
+			if err != nil {
+				yield(NamedAPIResource{}, err)
+				return
+			}
+			for _, result := range page.Results {
+				if !yield(result, nil) {
+					return
+				}
+			}
+			if page.Next == nil {
+				return
+			}
+			pageURL = page.Next
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/maniac-en/pokefetch/internal/cache"
+)
+
+// newListServer serves a list of total resources, pageSize at a time, and
+// counts the pages requested
+func newListServer(t *testing.T, total, pageSize int, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/api/v2/move" {
+			http.NotFound(w, r)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		next := "null"
+		if offset+pageSize < total {
+			next = fmt.Sprintf(`"%s/api/v2/move?offset=%d&limit=%d"`, server.URL, offset+pageSize, pageSize)
+		}
+		results := ""
+		for i := offset; i < min(offset+pageSize, total); i++ {
+			if results != "" {
+				results += ","
+			}
+			results += fmt.Sprintf(`{"name": "move-%d", "url": "%s/api/v2/move/%d/"}`, i, server.URL, i)
+		}
+		fmt.Fprintf(w, `{"count": %d, "next": %s, "previous": null, "results": [%s]}`, total, next, results)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestList_IteratesEveryPage(t *testing.T) {
+	var requests atomic.Int32
+	server := newListServer(t, 7, 3, &requests)
+	client := &Client{
+		cache:   cache.NewCache(time.Minute),
+		baseURL: server.URL + "/api",
+	}
+
+	var names []string
+	for resource, err := range client.List(context.Background(), "move") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, resource.Name)
+	}
+
+	if len(names) != 7 || names[0] != "move-0" || names[6] != "move-6" {
+		t.Errorf("expected move-0 to move-6, got %v", names)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 pages to be fetched, got %d", got)
+	}
+}
+
+func TestList_FetchesPagesLazily(t *testing.T) {
+	var requests atomic.Int32
+	server := newListServer(t, 7, 3, &requests)
+	client := &Client{
+		cache:   cache.NewCache(time.Minute),
+		baseURL: server.URL + "/api",
+	}
+
+	count := 0
+	for _, err := range client.List(context.Background(), "move") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected only the first page to be fetched, got %d requests", got)
+	}
+
+	// the first page is cached, iterating again fetches only what's new
+	count = 0
+	for range client.List(context.Background(), "move") {
+		count++
+		if count == 4 {
+			break
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected the first page to come from the cache, got %d requests", got)
+	}
+}
+
+func TestList_YieldsErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := newListServer(t, 7, 3, &requests)
+	client := &Client{
+		cache:   cache.NewCache(time.Minute),
+		baseURL: server.URL + "/api",
+	}
+
+	count := 0
+	for resource, err := range client.List(context.Background(), "nothing") {
+		count++
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected a not found error, got %v", err)
+		}
+		if resource.Name != "" {
+			t.Errorf("expected a zero resource along the error, got %+v", resource)
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected the error to be yielded once, got %d", count)
+	}
+
+	for _, err := range client.List(context.Background(), "") {
+		if err == nil {
+			t.Errorf("expected an error for an empty resource")
+		}
+	}
+}
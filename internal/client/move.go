@@ -0,0 +1,33 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Effect returns the short effect of the move in the given language, with its
+// chance filled in, e.g. "Has a 10% chance to paralyze the target."
+func (move Move) Effect(language string) (string, bool) {
+	effect, ok := shortEffect(move.EffectEntries, language)
+	if !ok {
+		return "", false
+	}
+	if move.EffectChance != nil {
+		effect = strings.ReplaceAll(effect, "$effect_chance", strconv.Itoa(*move.EffectChance))
+	}
+	return effect, true
+}
+
+// Effect returns the short effect of the ability in the given language
+func (ability Ability) Effect(language string) (string, bool) {
+	return shortEffect(ability.EffectEntries, language)
+}
+
+func shortEffect(entries []VerboseEffect, language string) (string, bool) {
+	for _, entry := range entries {
+		if entry.Language.Name == language {
+			return strings.Join(strings.Fields(entry.ShortEffect), " "), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/maniac-en/pokefetch/internal/cache"
+)
+
+func TestGetMove_Success(t *testing.T) {
+	moveName := "thunderbolt"
+	mockResponse := `{
+		"id": 85,
+		"name": "thunderbolt",
+		"accuracy": 100,
+		"power": 90,
+		"pp": 15,
+		"priority": 0,
+		"effect_chance": 10,
+		"damage_class": {"name": "special", "url": "https://pokeapi.co/api/v2/move-damage-class/3/"},
+		"type": {"name": "electric", "url": "https://pokeapi.co/api/v2/type/13/"},
+		"effect_entries": [
+			{
+				"effect": "Inflicts regular damage. Has a $effect_chance% chance to paralyze the target.",
+				"short_effect": "Has a $effect_chance% chance to\nparalyze the target.",
+				"language": {"name": "en", "url": "https://pokeapi.co/api/v2/language/9/"}
+			}
+		],
+		"learned_by_pokemon": [{"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon/25/"}]
+	}`
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/move/thunderbolt"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %s, got %s", expectedURL, req.URL.String())
+				}
+				return createResponse(http.StatusOK, mockResponse, map[string]string{}), nil
+			}),
+		},
+	}
+
+	move, err := client.GetMove(&moveName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if move.Power == nil || *move.Power != 90 {
+		t.Errorf("expected power 90, got %v", move.Power)
+	}
+	if move.DamageClass.Name != "special" {
+		t.Errorf("expected damage class special, got %s", move.DamageClass.Name)
+	}
+	effect, ok := move.Effect("en")
+	if !ok || effect != "Has a 10% chance to paralyze the target." {
+		t.Errorf("unexpected effect %q", effect)
+	}
+}
+
+func TestGetMove_StatusMoveHasNoPower(t *testing.T) {
+	moveName := "growl"
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return createResponse(http.StatusOK, `{"name": "growl", "power": null, "accuracy": 100, "pp": 40}`, map[string]string{}), nil
+			}),
+		},
+	}
+
+	move, err := client.GetMove(&moveName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if move.Power != nil {
+		t.Errorf("expected no power, got %d", *move.Power)
+	}
+}
+
+func TestGetAbility_Success(t *testing.T) {
+	abilityName := "static"
+	mockResponse := `{
+		"id": 9,
+		"name": "static",
+		"is_main_series": true,
+		"effect_entries": [
+			{
+				"effect": "Whenever a move makes contact with this Pokémon, the move's user has a 30% chance of being paralyzed.",
+				"short_effect": "Has a 30% chance of paralyzing attacking Pokémon on contact.",
+				"language": {"name": "en", "url": "https://pokeapi.co/api/v2/language/9/"}
+			}
+		],
+		"pokemon": [
+			{"is_hidden": false, "slot": 1, "pokemon": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon/25/"}},
+			{"is_hidden": true, "slot": 3, "pokemon": {"name": "electrike", "url": "https://pokeapi.co/api/v2/pokemon/309/"}}
+		]
+	}`
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/ability/static"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %s, got %s", expectedURL, req.URL.String())
+				}
+				return createResponse(http.StatusOK, mockResponse, map[string]string{}), nil
+			}),
+		},
+	}
+
+	ability, err := client.GetAbility(&abilityName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ability.Pokemon) != 2 || !ability.Pokemon[1].IsHidden {
+		t.Errorf("expected 2 pokemons, the second one with it hidden, got %+v", ability.Pokemon)
+	}
+	effect, ok := ability.Effect("en")
+	if !ok || effect != "Has a 30% chance of paralyzing attacking Pokémon on contact." {
+		t.Errorf("unexpected effect %q", effect)
+	}
+}
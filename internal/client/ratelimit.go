@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/maniac-en/pokefetch/internal/ratelimit"
+)
+
+// WithRateLimit throttles the client's network calls to rate requests per
+// second, with bursts of up to burst requests. Cache hits are never throttled.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(o *options) {
+		o.rateLimit = &rateLimitOptions{rate: rate, burst: burst}
+	}
+}
+
+type rateLimitOptions struct {
+	rate  float64
+	burst int
+}
+
+// RateLimitStats reports how much the rate limiter held the network calls back,
+// across all the client's callers, see WithRateLimitWait for a single call
+type RateLimitStats struct {
+	// Throttled counts the calls which had to wait for the limiter
+	Throttled int64
+	TotalWait time.Duration
+}
+
+// rateLimitCounters are the running totals behind RateLimitStats
+type rateLimitCounters struct {
+	throttled atomic.Int64
+	totalWait atomic.Int64
+}
+
+func (client *Client) RateLimitStats() RateLimitStats {
+	return RateLimitStats{
+		Throttled: client.rateLimitCounters.throttled.Load(),
+		TotalWait: time.Duration(client.rateLimitCounters.totalWait.Load()),
+	}
+}
+
+type rateLimitWaitKey struct{}
+
+// WithRateLimitWait returns a copy of ctx which reports to onWait how long each
+// network request made with it waited for the rate limiter, retries included.
+// Calls answered from the cache, or by joining an identical request already in
+// flight, make no request of their own and report nothing.
+func WithRateLimitWait(ctx context.Context, onWait func(time.Duration)) context.Context {
+	return context.WithValue(ctx, rateLimitWaitKey{}, onWait)
+}
+
+// waitForRateLimit blocks the network call until the limiter lets it through
+func (client *Client) waitForRateLimit(ctx context.Context) error {
+	if client.limiter == nil {
+		return nil
+	}
+	waited, err := client.limiter.Wait(ctx)
+	if onWait, ok := ctx.Value(rateLimitWaitKey{}).(func(time.Duration)); ok {
+		onWait(waited)
+	}
+	if waited > 0 {
+		client.rateLimitCounters.throttled.Add(1)
+		client.rateLimitCounters.totalWait.Add(int64(waited))
+	}
+	return err
+}
+
+func newLimiter(o *rateLimitOptions) (*ratelimit.Limiter, error) {
+	if o == nil {
+		return nil, nil
+	}
+	return ratelimit.NewLimiter(o.rate, o.burst)
+}
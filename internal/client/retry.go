@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how transient failures, i.e. network errors, 429 Too
+// Many Requests and 5xx responses, are retried. Only idempotent requests are
+// ever retried.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt too, 0 or 1 disables retrying
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled on every retry
+	// up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Jitter randomizes every wait by up to this fraction, e.g. 0.2 for ±20%,
+	// so many clients don't retry in lockstep
+	Jitter float64
+}
+
+// DefaultRetryPolicy is used by the clients created with NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	Jitter:      0.2,
+}
+
+// WithRetryPolicy overrides DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// transientError marks a failure worth retrying, retryAfter is the wait asked
+// for by the server through the Retry-After header, if any
+type transientError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+// isTransientStatus reports whether a response status is worth retrying
+func isTransientStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter reads the Retry-After header, given either in seconds or as
+// an HTTP date
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+// backoff returns the wait before the given retry, counted from 1
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	delay := policy.BaseDelay
+	// without a MaxDelay, the doubling stops short of overflowing, jitter included
+	for i := 1; i < retry && (policy.MaxDelay <= 0 || delay < policy.MaxDelay) && delay <= math.MaxInt64/4; i++ {
+		delay *= 2
+	}
+	if policy.MaxDelay > 0 {
+		delay = min(delay, policy.MaxDelay)
+	}
+	if policy.Jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * policy.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// nextDelay decides whether the failed attempt is retried and after how long,
+// a Retry-After beyond MaxDelay gives up rather than blocking for that long
+func (policy RetryPolicy) nextDelay(attempt int, method string, err error) (time.Duration, bool) {
+	var transient *transientError
+	if attempt >= policy.MaxAttempts || !errors.As(err, &transient) {
+		return 0, false
+	}
+	if method != http.MethodGet && method != http.MethodHead {
+		return 0, false
+	}
+	delay := policy.backoff(attempt)
+	if transient.retryAfter > 0 {
+		if policy.MaxDelay > 0 && transient.retryAfter > policy.MaxDelay {
+			return 0, false
+		}
+		delay = max(delay, transient.retryAfter)
+	}
+	return delay, true
+}
+
+// withRetries runs attempt until it succeeds, fails for good, the policy runs
+// out of attempts or ctx is done
+func withRetries[T any](ctx context.Context, policy RetryPolicy, method string, attempt func() (T, error)) (T, error) {
+	for n := 1; ; n++ {
+		result, err := attempt()
+		if err == nil {
+			return result, err
+		}
+		if ctx.Err() != nil {
+			return result, errors.Join(err, ctx.Err())
+		}
+		delay, ok := policy.nextDelay(n, method, err)
+		if !ok {
+			return result, err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return result, errors.Join(err, ctx.Err())
+		}
+	}
+}
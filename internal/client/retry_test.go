@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with status and the given
+// headers, then answers with a pokemon
+func flakyServer(failures int32, status int, headers map[string]string) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			for key, value := range headers {
+				w.Header().Set(key, value)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, `{"id": 25, "name": "pikachu"}`)
+	}))
+	return server, &requests
+}
+
+func TestFetch_Retries(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	tests := []struct {
+		name             string
+		failures         int32
+		status           int
+		headers          map[string]string
+		expectedRequests int32
+		expectedError    string
+	}{
+		{
+			name:             "recovers from a 503",
+			failures:         2,
+			status:           http.StatusServiceUnavailable,
+			expectedRequests: 3,
+		},
+		{
+			name:             "recovers from a 429 with Retry-After",
+			failures:         1,
+			status:           http.StatusTooManyRequests,
+			headers:          map[string]string{"Retry-After": "0"},
+			expectedRequests: 2,
+		},
+		{
+			name:             "gives up after max attempts",
+			failures:         5,
+			status:           http.StatusBadGateway,
+			expectedRequests: 3,
+			expectedError:    "received the response with 502 status",
+		},
+		{
+			name:             "gives up when Retry-After exceeds max delay",
+			failures:         1,
+			status:           http.StatusTooManyRequests,
+			headers:          map[string]string{"Retry-After": "120"},
+			expectedRequests: 1,
+			expectedError:    "received the response with 429 status",
+		},
+		{
+			name:             "never retries a 404",
+			failures:         1,
+			status:           http.StatusNotFound,
+			expectedRequests: 1,
+			expectedError:    "resource not found at",
+		},
+		{
+			name:             "never retries a 400",
+			failures:         1,
+			status:           http.StatusBadRequest,
+			expectedRequests: 1,
+			expectedError:    "received the response with 400 status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := flakyServer(tt.failures, tt.status, tt.headers)
+			defer server.Close()
+			url := server.URL + "/api/v2/pokemon/pikachu"
+
+			client, err := NewClient(5*time.Second, time.Minute, WithRetryPolicy(policy))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer client.Close()
+
+			result, err := GetResourceFromPokeAPI[Pokemon](client, &url)
+			if requests.Load() != tt.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tt.expectedRequests, requests.Load())
+			}
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.ID != 25 {
+				t.Errorf("expected ID 25, got %d", result.ID)
+			}
+		})
+	}
+}
+
+func TestFetch_RetriesNetworkErrors(t *testing.T) {
+	attempts := 0
+	client := &Client{
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				if attempts == 1 {
+					return nil, fmt.Errorf("connection reset by peer")
+				}
+				return createResponse(http.StatusOK, `{"id": 25}`, map[string]string{}), nil
+			}),
+		},
+		retryPolicy: RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+	}
+
+	res, err := client.fetch(context.Background(), "https://pokeapi.co/api/v2/pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(res.data) != `{"id": 25}` || attempts != 2 {
+		t.Errorf("expected a successful second attempt, got %q after %d attempts", res.data, attempts)
+	}
+}
+
+func TestFetch_StopsRetryingOnCancel(t *testing.T) {
+	server, requests := flakyServer(10, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+	client := &Client{
+		retryPolicy: RetryPolicy{MaxAttempts: 10, BaseDelay: time.Hour},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.fetch(ctx, server.URL+"/api/v2/pokemon/pikachu"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context's error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the backoff to stop with the context, took %v", elapsed)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected a single request, got %d", requests.Load())
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, delay := range expected {
+		if actual := policy.backoff(i + 1); actual != delay {
+			t.Errorf("retry %d: expected %v, got %v", i+1, delay, actual)
+		}
+	}
+
+	uncapped := RetryPolicy{BaseDelay: 100 * time.Millisecond}
+	if actual := uncapped.backoff(5); actual != 1600*time.Millisecond {
+		t.Errorf("expected the delay to keep doubling without a MaxDelay, got %v", actual)
+	}
+	if actual := uncapped.backoff(100); actual <= 0 {
+		t.Errorf("expected a late retry to not overflow, got %v", actual)
+	}
+
+	policy.Jitter = 0.5
+	for range 100 {
+		if actual := policy.backoff(1); actual < 50*time.Millisecond || actual > 150*time.Millisecond {
+			t.Fatalf("expected the jittered delay within ±50%%, got %v", actual)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+	tests := []struct {
+		header   string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{"Wed, 21 Oct 2015 07:28:10 GMT", 10 * time.Second},
+		{"Wed, 21 Oct 2015 07:27:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if actual := parseRetryAfter(tt.header, now); actual != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
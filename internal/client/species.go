@@ -0,0 +1,28 @@
+package client
+
+import "strings"
+
+// DefaultLanguage is the language picked out of the localized PokeAPI texts
+const DefaultLanguage string = "en"
+
+// FlavorText returns the most recent Pokedex entry of the species in the given
+// language, with the line breaks of the game's text boxes flattened
+func (species PokemonSpecies) FlavorText(language string) (string, bool) {
+	for i := len(species.FlavorTextEntries) - 1; i >= 0; i-- {
+		entry := species.FlavorTextEntries[i]
+		if entry.Language.Name == language {
+			return strings.Join(strings.Fields(entry.FlavorText), " "), true
+		}
+	}
+	return "", false
+}
+
+// Genus returns the species' genus in the given language, e.g. "Mouse Pokémon"
+func (species PokemonSpecies) Genus(language string) (string, bool) {
+	for _, genus := range species.Genera {
+		if genus.Language.Name == language {
+			return genus.Genus, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/maniac-en/pokefetch/internal/cache"
+)
+
+const pikachuSpecies = `{
+	"id": 25,
+	"name": "pikachu",
+	"capture_rate": 190,
+	"base_happiness": 50,
+	"is_legendary": false,
+	"is_mythical": false,
+	"color": {"name": "yellow", "url": "https://pokeapi.co/api/v2/pokemon-color/10/"},
+	"habitat": {"name": "forest", "url": "https://pokeapi.co/api/v2/pokemon-habitat/2/"},
+	"generation": {"name": "generation-i", "url": "https://pokeapi.co/api/v2/generation/1/"},
+	"evolves_from_species": {"name": "pichu", "url": "https://pokeapi.co/api/v2/pokemon-species/172/"},
+	"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/10/"},
+	"flavor_text_entries": [
+		{
+			"flavor_text": "When several of\nthese POKéMON\fgather, their\nelectricity could\nbuild and cause\nlightning storms.",
+			"language": {"name": "en", "url": "https://pokeapi.co/api/v2/language/9/"},
+			"version": {"name": "red", "url": "https://pokeapi.co/api/v2/version/1/"}
+		},
+		{
+			"flavor_text": "It stores electricity\nin its cheeks.",
+			"language": {"name": "en", "url": "https://pokeapi.co/api/v2/language/9/"},
+			"version": {"name": "sword", "url": "https://pokeapi.co/api/v2/version/33/"}
+		},
+		{
+			"flavor_text": "Il stocke l'électricité.",
+			"language": {"name": "fr", "url": "https://pokeapi.co/api/v2/language/5/"},
+			"version": {"name": "sword", "url": "https://pokeapi.co/api/v2/version/33/"}
+		}
+	],
+	"genera": [
+		{"genus": "Pokémon Souris", "language": {"name": "fr", "url": "https://pokeapi.co/api/v2/language/5/"}},
+		{"genus": "Mouse Pokémon", "language": {"name": "en", "url": "https://pokeapi.co/api/v2/language/9/"}}
+	]
+}`
+
+func TestGetPokemonSpecies_Success(t *testing.T) {
+	speciesName := "pikachu"
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				expectedURL := "https://pokeapi.co/api/v2/pokemon-species/pikachu"
+				if req.URL.String() != expectedURL {
+					t.Errorf("expected URL %s, got %s", expectedURL, req.URL.String())
+				}
+				return createResponse(http.StatusOK, pikachuSpecies, map[string]string{
+					"Content-Type": "application/json",
+				}), nil
+			}),
+		},
+	}
+
+	species, err := client.GetPokemonSpecies(&speciesName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if species.CaptureRate != 190 {
+		t.Errorf("expected capture rate 190, got %d", species.CaptureRate)
+	}
+	if species.Habitat == nil || species.Habitat.Name != "forest" {
+		t.Errorf("expected habitat forest, got %+v", species.Habitat)
+	}
+	if species.EvolvesFromSpecies == nil || species.EvolvesFromSpecies.Name != "pichu" {
+		t.Errorf("expected to evolve from pichu, got %+v", species.EvolvesFromSpecies)
+	}
+	if species.EvolutionChain.URL != "https://pokeapi.co/api/v2/evolution-chain/10/" {
+		t.Errorf("unexpected evolution chain %s", species.EvolutionChain.URL)
+	}
+}
+
+func TestPokemonSpecies_LocalizedTexts(t *testing.T) {
+	client := &Client{
+		cache: cache.NewCache(3 * time.Second),
+		httpClient: http.Client{
+			Transport: mockTransport(func(req *http.Request) (*http.Response, error) {
+				return createResponse(http.StatusOK, pikachuSpecies, map[string]string{}), nil
+			}),
+		},
+	}
+	speciesName := "pikachu"
+	species, err := client.GetPokemonSpecies(&speciesName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	flavorText, ok := species.FlavorText("en")
+	if !ok || flavorText != "It stores electricity in its cheeks." {
+		t.Errorf("expected the latest english entry flattened, got %q", flavorText)
+	}
+	genus, ok := species.Genus("en")
+	if !ok || genus != "Mouse Pokémon" {
+		t.Errorf("expected genus 'Mouse Pokémon', got %q", genus)
+	}
+	if _, ok := species.Genus("de"); ok {
+		t.Errorf("expected no german genus")
+	}
+}
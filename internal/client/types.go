@@ -1,15 +1,17 @@
 package client
 
-type PokeMapAreas struct {
-	Count    int     `json:"count"`
-	Next     *string `json:"next"`
-	Previous *string `json:"previous"`
-	Results  []struct {
-		Name string `json:"name"`
-		URL  string `json:"url"`
-	} `json:"results"`
+// NamedResourceList is a page of any PokeAPI list endpoint, Next and Previous
+// are nil on the last and the first page
+type NamedResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
 }
 
+// PokeMapAreas is a page of the location areas
+type PokeMapAreas = NamedResourceList
+
 type PokeMapArea struct {
 	EncounterMethodRates []struct {
 		EncounterMethod struct {
@@ -88,8 +90,15 @@ type Pokemon struct {
 			URL  string `json:"url"`
 		} `json:"version"`
 	} `json:"game_indices"`
-	Height                 int    `json:"height"`
-	HeldItems              []any  `json:"held_items"`
+	Height    int `json:"height"`
+	HeldItems []struct {
+		Item           NamedAPIResource `json:"item"`
+		VersionDetails []struct {
+			// Rarity is the chance in percent of a wild pokemon holding the item
+			Rarity  int              `json:"rarity"`
+			Version NamedAPIResource `json:"version"`
+		} `json:"version_details"`
+	} `json:"held_items"`
 	ID                     int    `json:"id"`
 	IsDefault              bool   `json:"is_default"`
 	LocationAreaEncounters string `json:"location_area_encounters"`
@@ -332,3 +341,236 @@ type Pokemon struct {
 	} `json:"types"`
 	Weight int `json:"weight"`
 }
+
+// NamedAPIResource is how PokeAPI refers to another resource by name
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// APIResource is how PokeAPI refers to another resource which has no name
+type APIResource struct {
+	URL string `json:"url"`
+}
+
+type PokemonSpecies struct {
+	ID                   int                `json:"id"`
+	Name                 string             `json:"name"`
+	Order                int                `json:"order"`
+	GenderRate           int                `json:"gender_rate"`
+	CaptureRate          int                `json:"capture_rate"`
+	BaseHappiness        int                `json:"base_happiness"`
+	IsBaby               bool               `json:"is_baby"`
+	IsLegendary          bool               `json:"is_legendary"`
+	IsMythical           bool               `json:"is_mythical"`
+	HatchCounter         int                `json:"hatch_counter"`
+	HasGenderDifferences bool               `json:"has_gender_differences"`
+	GrowthRate           NamedAPIResource   `json:"growth_rate"`
+	EggGroups            []NamedAPIResource `json:"egg_groups"`
+	Color                NamedAPIResource   `json:"color"`
+	Shape                *NamedAPIResource  `json:"shape"`
+	// Habitat and EvolvesFromSpecies are nil when the species has none
+	Habitat            *NamedAPIResource `json:"habitat"`
+	EvolvesFromSpecies *NamedAPIResource `json:"evolves_from_species"`
+	EvolutionChain     APIResource       `json:"evolution_chain"`
+	Generation         NamedAPIResource  `json:"generation"`
+	Names              []struct {
+		Name     string           `json:"name"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"names"`
+	FlavorTextEntries []struct {
+		FlavorText string           `json:"flavor_text"`
+		Language   NamedAPIResource `json:"language"`
+		Version    NamedAPIResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Genera []struct {
+		Genus    string           `json:"genus"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"genera"`
+	Varieties []struct {
+		IsDefault bool             `json:"is_default"`
+		Pokemon   NamedAPIResource `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+type EvolutionChain struct {
+	ID              int               `json:"id"`
+	BabyTriggerItem *NamedAPIResource `json:"baby_trigger_item"`
+	Chain           ChainLink         `json:"chain"`
+}
+
+// ChainLink is a species in an evolution chain, along with the species it
+// evolves to, more than one when the chain branches (e.g. eevee)
+type ChainLink struct {
+	IsBaby  bool             `json:"is_baby"`
+	Species NamedAPIResource `json:"species"`
+	// EvolutionDetails are the ways to evolve into this species, there's none
+	// for the first species of the chain
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail is one way to trigger an evolution, the conditions which
+// don't apply are left nil or zero
+type EvolutionDetail struct {
+	Trigger               NamedAPIResource  `json:"trigger"`
+	Item                  *NamedAPIResource `json:"item"`
+	Gender                *int              `json:"gender"`
+	HeldItem              *NamedAPIResource `json:"held_item"`
+	KnownMove             *NamedAPIResource `json:"known_move"`
+	KnownMoveType         *NamedAPIResource `json:"known_move_type"`
+	Location              *NamedAPIResource `json:"location"`
+	MinLevel              *int              `json:"min_level"`
+	MinHappiness          *int              `json:"min_happiness"`
+	MinBeauty             *int              `json:"min_beauty"`
+	MinAffection          *int              `json:"min_affection"`
+	NeedsOverworldRain    bool              `json:"needs_overworld_rain"`
+	PartySpecies          *NamedAPIResource `json:"party_species"`
+	PartyType             *NamedAPIResource `json:"party_type"`
+	RelativePhysicalStats *int              `json:"relative_physical_stats"`
+	TimeOfDay             string            `json:"time_of_day"`
+	TradeSpecies          *NamedAPIResource `json:"trade_species"`
+	TurnUpsideDown        bool              `json:"turn_upside_down"`
+}
+
+type Type struct {
+	ID              int               `json:"id"`
+	Name            string            `json:"name"`
+	DamageRelations TypeRelations     `json:"damage_relations"`
+	Generation      NamedAPIResource  `json:"generation"`
+	MoveDamageClass *NamedAPIResource `json:"move_damage_class"`
+	Pokemon         []struct {
+		Slot    int              `json:"slot"`
+		Pokemon NamedAPIResource `json:"pokemon"`
+	} `json:"pokemon"`
+	Moves []NamedAPIResource `json:"moves"`
+}
+
+// TypeRelations lists the types a type deals no, half and double damage to
+// when attacking, and takes it from when defending
+type TypeRelations struct {
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+}
+
+// VerboseEffect is the effect of a move or an ability in one language
+type VerboseEffect struct {
+	Effect      string           `json:"effect"`
+	ShortEffect string           `json:"short_effect"`
+	Language    NamedAPIResource `json:"language"`
+}
+
+type Move struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Accuracy, Power and PP are nil for the moves which don't have them,
+	// e.g. the status moves which never miss
+	Accuracy     *int             `json:"accuracy"`
+	Power        *int             `json:"power"`
+	PP           *int             `json:"pp"`
+	Priority     int              `json:"priority"`
+	EffectChance *int             `json:"effect_chance"`
+	DamageClass  NamedAPIResource `json:"damage_class"`
+	Type         NamedAPIResource `json:"type"`
+	Target       NamedAPIResource `json:"target"`
+	Generation   NamedAPIResource `json:"generation"`
+	Meta         *struct {
+		Ailment       NamedAPIResource `json:"ailment"`
+		Category      NamedAPIResource `json:"category"`
+		MinHits       *int             `json:"min_hits"`
+		MaxHits       *int             `json:"max_hits"`
+		Drain         int              `json:"drain"`
+		Healing       int              `json:"healing"`
+		CritRate      int              `json:"crit_rate"`
+		AilmentChance int              `json:"ailment_chance"`
+		FlinchChance  int              `json:"flinch_chance"`
+		StatChance    int              `json:"stat_chance"`
+	} `json:"meta"`
+	EffectEntries     []VerboseEffect `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText   string           `json:"flavor_text"`
+		Language     NamedAPIResource `json:"language"`
+		VersionGroup NamedAPIResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	LearnedByPokemon []NamedAPIResource `json:"learned_by_pokemon"`
+}
+
+type Ability struct {
+	ID                int              `json:"id"`
+	Name              string           `json:"name"`
+	IsMainSeries      bool             `json:"is_main_series"`
+	Generation        NamedAPIResource `json:"generation"`
+	EffectEntries     []VerboseEffect  `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText   string           `json:"flavor_text"`
+		Language     NamedAPIResource `json:"language"`
+		VersionGroup NamedAPIResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Pokemon []struct {
+		IsHidden bool             `json:"is_hidden"`
+		Slot     int              `json:"slot"`
+		Pokemon  NamedAPIResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+type Item struct {
+	ID          int                `json:"id"`
+	Name        string             `json:"name"`
+	Cost        int                `json:"cost"`
+	FlingPower  *int               `json:"fling_power"`
+	FlingEffect *NamedAPIResource  `json:"fling_effect"`
+	Attributes  []NamedAPIResource `json:"attributes"`
+	// Category groups the items by use, e.g. "standard-balls" or "evolution"
+	Category          NamedAPIResource `json:"category"`
+	EffectEntries     []VerboseEffect  `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		Text         string           `json:"text"`
+		Language     NamedAPIResource `json:"language"`
+		VersionGroup NamedAPIResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	HeldByPokemon []struct {
+		Pokemon        NamedAPIResource `json:"pokemon"`
+		VersionDetails []struct {
+			Rarity  int              `json:"rarity"`
+			Version NamedAPIResource `json:"version"`
+		} `json:"version_details"`
+	} `json:"held_by_pokemon"`
+	Sprites struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+}
+
+type Berry struct {
+	ID               int              `json:"id"`
+	Name             string           `json:"name"`
+	GrowthTime       int              `json:"growth_time"`
+	MaxHarvest       int              `json:"max_harvest"`
+	NaturalGiftPower int              `json:"natural_gift_power"`
+	Size             int              `json:"size"`
+	Smoothness       int              `json:"smoothness"`
+	SoilDryness      int              `json:"soil_dryness"`
+	Firmness         NamedAPIResource `json:"firmness"`
+	Flavors          []struct {
+		Potency int              `json:"potency"`
+		Flavor  NamedAPIResource `json:"flavor"`
+	} `json:"flavors"`
+	// Item is the item the berry is used as, e.g. "cheri-berry" for "cheri"
+	Item            NamedAPIResource `json:"item"`
+	NaturalGiftType NamedAPIResource `json:"natural_gift_type"`
+}
+
+type Version struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// VersionGroup is what the moves are learned per, e.g. "red-blue" for both
+	// red and blue
+	VersionGroup NamedAPIResource `json:"version_group"`
+	Names        []struct {
+		Name     string           `json:"name"`
+		Language NamedAPIResource `json:"language"`
+	} `json:"names"`
+}
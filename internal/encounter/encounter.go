@@ -0,0 +1,125 @@
+// Package encounter rolls the wild pokemons met in a location area, as often
+// as the area's encounter chances say
+package encounter
+
+import (
+	"slices"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+// DefaultMethod is the encounter method used when none is asked for
+const DefaultMethod string = "walk"
+
+// RNG is the source of randomness of the rolls, *rand.Rand satisfies it
+type RNG interface {
+	// IntN returns a number in [0, n)
+	IntN(n int) int
+}
+
+// Slot is one way to meet a pokemon in an area, Chance is its weight among
+// the slots of the same method
+type Slot struct {
+	Pokemon  string
+	Method   string
+	Version  string
+	Chance   int
+	MinLevel int
+	MaxLevel int
+}
+
+// Encounter is a rolled wild pokemon
+type Encounter struct {
+	Pokemon string
+	Method  string
+	Level   int
+}
+
+// Slots flattens the encounter details of an area
+func Slots(area client.PokeMapArea) []Slot {
+	var slots []Slot
+	for _, pokemonEncounter := range area.PokemonEncounters {
+		for _, versionDetails := range pokemonEncounter.VersionDetails {
+			for _, details := range versionDetails.EncounterDetails {
+				slots = append(slots, Slot{
+					Pokemon:  pokemonEncounter.Pokemon.Name,
+					Method:   details.Method.Name,
+					Version:  versionDetails.Version.Name,
+					Chance:   details.Chance,
+					MinLevel: details.MinLevel,
+					MaxLevel: details.MaxLevel,
+				})
+			}
+		}
+	}
+	return slots
+}
+
+// ForVersion keeps the slots of a game version, an empty version keeps them all
+func ForVersion(slots []Slot, version string) []Slot {
+	if version == "" {
+		return slots
+	}
+	var kept []Slot
+	for _, slot := range slots {
+		if slot.Version == version {
+			kept = append(kept, slot)
+		}
+	}
+	return kept
+}
+
+// Methods returns the encounter methods of the slots, sorted
+func Methods(slots []Slot) []string {
+	var methods []string
+	for _, slot := range slots {
+		if !slices.Contains(methods, slot.Method) {
+			methods = append(methods, slot.Method)
+		}
+	}
+	slices.Sort(methods)
+	return methods
+}
+
+// Roll picks a slot of the method weighted by its chance, and a level in the
+// slot's range. The chances only add up within a game version, so with slots of
+// several versions one of them is picked first, each as likely as the others.
+// It reports false when no slot of the method can be met.
+func Roll(rng RNG, slots []Slot, method string) (Encounter, bool) {
+	var versions []string
+	for _, slot := range slots {
+		if slot.Method == method && slot.Chance > 0 && !slices.Contains(versions, slot.Version) {
+			versions = append(versions, slot.Version)
+		}
+	}
+	if len(versions) == 0 {
+		return Encounter{}, false
+	}
+	slices.Sort(versions)
+	version := versions[0]
+	if len(versions) > 1 {
+		version = versions[rng.IntN(len(versions))]
+	}
+
+	var pool []Slot
+	total := 0
+	for _, slot := range slots {
+		if slot.Method == method && slot.Chance > 0 && slot.Version == version {
+			pool = append(pool, slot)
+			total += slot.Chance
+		}
+	}
+	roll := rng.IntN(total)
+	for _, slot := range pool {
+		if roll >= slot.Chance {
+			roll -= slot.Chance
+			continue
+		}
+		level := slot.MinLevel
+		if slot.MaxLevel > slot.MinLevel {
+			level += rng.IntN(slot.MaxLevel - slot.MinLevel + 1)
+		}
+		return Encounter{Pokemon: slot.Pokemon, Method: method, Level: max(level, 1)}, true
+	}
+	return Encounter{}, false
+}
@@ -0,0 +1,177 @@
+package encounter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+// sequenceRNG returns the given numbers in turn
+type sequenceRNG struct {
+	values []int
+	draws  int
+}
+
+func (r *sequenceRNG) IntN(n int) int {
+	value := r.values[r.draws%len(r.values)]
+	r.draws++
+	return value % n
+}
+
+func TestSlots(t *testing.T) {
+	var area client.PokeMapArea
+	data := `{
+		"name": "viridian-forest-area",
+		"pokemon_encounters": [
+			{
+				"pokemon": {"name": "caterpie", "url": ""},
+				"version_details": [
+					{
+						"version": {"name": "red", "url": ""},
+						"max_chance": 50,
+						"encounter_details": [
+							{"chance": 50, "min_level": 3, "max_level": 5, "method": {"name": "walk", "url": ""}, "condition_values": []}
+						]
+					}
+				]
+			},
+			{
+				"pokemon": {"name": "pikachu", "url": ""},
+				"version_details": [
+					{
+						"version": {"name": "red", "url": ""},
+						"max_chance": 5,
+						"encounter_details": [
+							{"chance": 5, "min_level": 3, "max_level": 3, "method": {"name": "walk", "url": ""}, "condition_values": []}
+						]
+					}
+				]
+			}
+		]
+	}`
+	if err := json.Unmarshal([]byte(data), &area); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Slot{
+		{Pokemon: "caterpie", Method: "walk", Version: "red", Chance: 50, MinLevel: 3, MaxLevel: 5},
+		{Pokemon: "pikachu", Method: "walk", Version: "red", Chance: 5, MinLevel: 3, MaxLevel: 3},
+	}
+	if got := Slots(area); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestRoll(t *testing.T) {
+	slots := []Slot{
+		{Pokemon: "caterpie", Method: "walk", Chance: 50, MinLevel: 3, MaxLevel: 5},
+		{Pokemon: "magikarp", Method: "old-rod", Chance: 100, MinLevel: 5, MaxLevel: 5},
+		{Pokemon: "pikachu", Method: "walk", Chance: 5, MinLevel: 3, MaxLevel: 3},
+	}
+	tests := []struct {
+		name     string
+		method   string
+		rolls    []int
+		expected Encounter
+		ok       bool
+	}{
+		{
+			name:     "first slot with its lowest level",
+			method:   "walk",
+			rolls:    []int{0, 0},
+			expected: Encounter{Pokemon: "caterpie", Method: "walk", Level: 3},
+			ok:       true,
+		},
+		{
+			name:     "first slot with its highest level",
+			method:   "walk",
+			rolls:    []int{49, 2},
+			expected: Encounter{Pokemon: "caterpie", Method: "walk", Level: 5},
+			ok:       true,
+		},
+		{
+			name:     "rare slot",
+			method:   "walk",
+			rolls:    []int{50},
+			expected: Encounter{Pokemon: "pikachu", Method: "walk", Level: 3},
+			ok:       true,
+		},
+		{
+			name:     "other method",
+			method:   "old-rod",
+			rolls:    []int{99},
+			expected: Encounter{Pokemon: "magikarp", Method: "old-rod", Level: 5},
+			ok:       true,
+		},
+		{
+			name:   "method not found in the area",
+			method: "surf",
+			rolls:  []int{0},
+			ok:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encounter, ok := Roll(&sequenceRNG{values: tt.rolls}, slots, tt.method)
+			if ok != tt.ok || encounter != tt.expected {
+				t.Errorf("expected (%+v, %v), got (%+v, %v)", tt.expected, tt.ok, encounter, ok)
+			}
+		})
+	}
+}
+
+func TestRoll_EveryVersionWeighsTheSame(t *testing.T) {
+	// pidgey is met in 3 versions and pikachu only in yellow, pooling the
+	// versions' chances would meet pikachu 1 time in 12 instead of 1 in 6
+	slots := []Slot{
+		{Pokemon: "pidgey", Method: "walk", Version: "blue", Chance: 100, MinLevel: 3, MaxLevel: 3},
+		{Pokemon: "pidgey", Method: "walk", Version: "red", Chance: 100, MinLevel: 3, MaxLevel: 3},
+		{Pokemon: "pidgey", Method: "walk", Version: "yellow", Chance: 20, MinLevel: 3, MaxLevel: 3},
+		{Pokemon: "pikachu", Method: "walk", Version: "yellow", Chance: 20, MinLevel: 3, MaxLevel: 3},
+	}
+
+	met := map[string]int{}
+	for version := range 3 {
+		for roll := range 200 {
+			encounter, ok := Roll(&sequenceRNG{values: []int{version, roll}}, slots, "walk")
+			if !ok {
+				t.Fatalf("expected an encounter")
+			}
+			met[encounter.Pokemon]++
+		}
+	}
+	// every version is picked as often, and within yellow half of the rolls
+	// meet pikachu: 500 pidgeys for 100 pikachus out of 600 rolls
+	if met["pidgey"] != 500 || met["pikachu"] != 100 {
+		t.Errorf("expected 500 pidgeys and 100 pikachus, got %v", met)
+	}
+}
+
+func TestMethods(t *testing.T) {
+	slots := []Slot{{Method: "walk"}, {Method: "old-rod"}, {Method: "walk"}}
+	if got := Methods(slots); !reflect.DeepEqual(got, []string{"old-rod", "walk"}) {
+		t.Errorf("expected old-rod and walk, got %v", got)
+	}
+}
+
+func TestForVersion(t *testing.T) {
+	slots := []Slot{
+		{Pokemon: "pikachu", Version: "red"},
+		{Pokemon: "pikachu", Version: "yellow"},
+		{Pokemon: "caterpie", Version: "red"},
+	}
+
+	red := ForVersion(slots, "red")
+	if len(red) != 2 || red[0].Version != "red" || red[1].Version != "red" {
+		t.Errorf("expected the 2 red slots, got %+v", red)
+	}
+	if got := ForVersion(slots, "emerald"); len(got) != 0 {
+		t.Errorf("expected no emerald slot, got %+v", got)
+	}
+	if got := ForVersion(slots, ""); len(got) != len(slots) {
+		t.Errorf("expected every slot without a version, got %+v", got)
+	}
+}
@@ -0,0 +1,61 @@
+// Package inventory keeps track of the items the trainer carries around
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrOutOfItem = errors.New("no such item left in the bag")
+
+// Inventory maps the PokeAPI item names, e.g. "poke-ball", to how many of
+// them the trainer has, items run out are removed
+type Inventory map[string]int
+
+func New() Inventory {
+	return make(Inventory)
+}
+
+// Starter is the bag every new trainer sets off with
+func Starter() Inventory {
+	return Inventory{
+		"poke-ball":  10,
+		"great-ball": 5,
+		"ultra-ball": 2,
+	}
+}
+
+// Add puts quantity more of the item in the bag
+func (inv Inventory) Add(item string, quantity int) {
+	if quantity <= 0 {
+		return
+	}
+	inv[item] += quantity
+}
+
+// Take removes one of the item from the bag
+func (inv Inventory) Take(item string) error {
+	if inv[item] <= 0 {
+		return fmt.Errorf("%w: %s", ErrOutOfItem, item)
+	}
+	inv[item]--
+	if inv[item] == 0 {
+		delete(inv, item)
+	}
+	return nil
+}
+
+func (inv Inventory) Count(item string) int {
+	return inv[item]
+}
+
+// Items returns the names of the items in the bag, sorted
+func (inv Inventory) Items() []string {
+	items := make([]string, 0, len(inv))
+	for item := range inv {
+		items = append(items, item)
+	}
+	slices.Sort(items)
+	return items
+}
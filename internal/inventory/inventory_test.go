@@ -0,0 +1,39 @@
+package inventory
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInventory_AddAndTake(t *testing.T) {
+	inv := New()
+	inv.Add("potion", 2)
+	inv.Add("poke-ball", 1)
+	inv.Add("rare-candy", 0)
+
+	if got := inv.Items(); !reflect.DeepEqual(got, []string{"poke-ball", "potion"}) {
+		t.Errorf("expected poke-ball and potion, got %v", got)
+	}
+	if err := inv.Take("potion"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := inv.Count("potion"); got != 1 {
+		t.Errorf("expected 1 potion left, got %d", got)
+	}
+	if err := inv.Take("poke-ball"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := inv["poke-ball"]; ok {
+		t.Errorf("expected the used up poke-ball to be removed")
+	}
+	if err := inv.Take("poke-ball"); !errors.Is(err, ErrOutOfItem) {
+		t.Errorf("expected ErrOutOfItem, got %v", err)
+	}
+}
+
+func TestStarter(t *testing.T) {
+	if Starter().Count("poke-ball") == 0 {
+		t.Errorf("expected the starter bag to have poke-balls")
+	}
+}
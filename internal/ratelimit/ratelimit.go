@@ -0,0 +1,84 @@
+// Package ratelimit implements a token bucket, used to keep the PokeAPI calls
+// within its fair use policy no matter how many goroutines make them
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket refilled at rate tokens per second, holding up to
+// burst tokens. It's safe for concurrent use.
+type Limiter struct {
+	mu     *sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// now is swapped in tests
+	now func() time.Time
+}
+
+func NewLimiter(rate float64, burst int) (*Limiter, error) {
+	if rate <= 0 {
+		return nil, fmt.Errorf("rate must be positive")
+	}
+	if burst <= 0 {
+		return nil, fmt.Errorf("burst must be positive")
+	}
+	return &Limiter{
+		mu:     &sync.Mutex{},
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}, nil
+}
+
+// reserve takes a token, going into debt if there's none left, and returns how
+// long to wait for the debt to be paid back
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel gives back a token reserved by a caller which stopped waiting for it
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+// Wait blocks until a token is available or ctx is done, and returns how long
+// it blocked
+func (l *Limiter) Wait(ctx context.Context) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	delay := l.reserve()
+	if delay == 0 {
+		return 0, nil
+	}
+	start := time.Now()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return time.Since(start), nil
+	case <-ctx.Done():
+		l.cancel()
+		return time.Since(start), ctx.Err()
+	}
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewLimiter(t *testing.T) {
+	tests := []struct {
+		name        string
+		rate        float64
+		burst       int
+		expectError bool
+	}{
+		{name: "valid parameters", rate: 10, burst: 5},
+		{name: "zero rate", rate: 0, burst: 5, expectError: true},
+		{name: "negative burst", rate: 10, burst: -1, expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter, err := NewLimiter(tt.rate, tt.burst)
+			if tt.expectError != (err != nil) {
+				t.Errorf("expected error %v, got %v", tt.expectError, err)
+			}
+			if tt.expectError && limiter != nil {
+				t.Errorf("expected nil limiter on error, got %v", limiter)
+			}
+		})
+	}
+}
+
+func TestLimiter_Reserve(t *testing.T) {
+	limiter, err := NewLimiter(10, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	expected := []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond}
+	for i, delay := range expected {
+		if actual := limiter.reserve(); actual != delay {
+			t.Errorf("call %d: expected a %v wait, got %v", i+1, delay, actual)
+		}
+	}
+
+	// a second later the debt is paid back and the bucket is full again
+	now = now.Add(time.Second)
+	for i := range 2 {
+		if actual := limiter.reserve(); actual != 0 {
+			t.Errorf("call %d after refill: expected no wait, got %v", i+1, actual)
+		}
+	}
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	limiter, err := NewLimiter(100, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if waited, err := limiter.Wait(context.Background()); err != nil || waited != 0 {
+		t.Fatalf("expected the first call to go through, waited %v (%v)", waited, err)
+	}
+	waited, err := limiter.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if waited < 5*time.Millisecond {
+		t.Errorf("expected the second call to wait about 10ms, waited %v", waited)
+	}
+}
+
+func TestLimiter_WaitCancelled(t *testing.T) {
+	limiter, err := NewLimiter(1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	// the cancelled call gave its token back, so the debt is a single token
+	if delay := limiter.reserve(); delay > time.Second {
+		t.Errorf("expected at most a second of debt, got %v", delay)
+	}
+}
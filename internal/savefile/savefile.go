@@ -0,0 +1,195 @@
+// Package savefile persists the trainer's progress (caught pokemons, etc.) to
+// disk as versioned JSON, so it survives across the PokeFetch sessions
+package savefile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+	"github.com/maniac-en/pokefetch/internal/inventory"
+)
+
+const (
+	// CurrentVersion is the save file schema version written by this build
+	CurrentVersion int    = 2
+	appDirName     string = "pokefetch"
+	fileName       string = "pokedex.json"
+)
+
+var (
+	ErrCorrupt            = errors.New("save file is corrupt")
+	ErrUnsupportedVersion = errors.New("unsupported save file version")
+)
+
+type SaveFile struct {
+	Version        int                       `json:"version"`
+	SavedAt        time.Time                 `json:"saved_at"`
+	Pokedex        map[string]client.Pokemon `json:"pokedex"`
+	NextMapAreaURL *string                   `json:"next_map_area_url,omitempty"`
+	PrevMapAreaURL *string                   `json:"prev_map_area_url,omitempty"`
+	Inventory      inventory.Inventory       `json:"inventory"`
+	// Seed is the seed of the session which wrote the save file, it's only kept
+	// for reference as replaying a session takes its transcript, which records
+	// the state the session started from along with the seed
+	Seed uint64 `json:"seed,omitempty"`
+}
+
+// migration upgrades the raw save file from one version to the next one
+type migration func(json.RawMessage) (json.RawMessage, error)
+
+// migrations maps a version to the migration which upgrades it to version+1,
+// every bump of CurrentVersion must come with an entry here
+var migrations = map[int]migration{
+	1: addInventory,
+}
+
+// addInventory hands the trainers saved before items existed the starter bag
+func addInventory(data json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	starter, err := json.Marshal(inventory.Starter())
+	if err != nil {
+		return nil, err
+	}
+	fields["version"] = json.RawMessage("2")
+	fields["inventory"] = starter
+	return json.Marshal(fields)
+}
+
+func New() *SaveFile {
+	return &SaveFile{
+		Version:   CurrentVersion,
+		Pokedex:   make(map[string]client.Pokemon),
+		Inventory: inventory.Starter(),
+	}
+}
+
+// DefaultDir returns the PokeFetch directory under the user's config dir
+func DefaultDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config dir: %w", err)
+	}
+	return filepath.Join(configDir, appDirName), nil
+}
+
+// DefaultPath returns the autosave file location under DefaultDir
+func DefaultPath() (string, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// Load reads the save file at path, migrating it to CurrentVersion if it was
+// written by an older build. A missing file is reported with an error
+// matching fs.ErrNotExist.
+func Load(path string) (*SaveFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save file: %w", err)
+	}
+	return Decode(data)
+}
+
+// Decode parses the content of a save file, migrating it to CurrentVersion if
+// it was written by an older build
+func Decode(data []byte) (*SaveFile, error) {
+	data, err := migrate(data)
+	if err != nil {
+		return nil, err
+	}
+	// not New, its starter bag would get merged into the saved one
+	saveFile := &SaveFile{}
+	if err := json.Unmarshal(data, saveFile); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	if saveFile.Pokedex == nil {
+		saveFile.Pokedex = make(map[string]client.Pokemon)
+	}
+	if saveFile.Inventory == nil {
+		saveFile.Inventory = inventory.New()
+	}
+	return saveFile, nil
+}
+
+func migrate(data []byte) ([]byte, error) {
+	var header struct {
+		Version *int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	if header.Version == nil {
+		return nil, fmt.Errorf("%w: missing version", ErrCorrupt)
+	}
+	version := *header.Version
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("%w: version %d was written by a newer PokeFetch (this one supports up to %d)",
+			ErrUnsupportedVersion, version, CurrentVersion)
+	}
+	for ; version < CurrentVersion; version++ {
+		upgrade, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: no migration from version %d", ErrUnsupportedVersion, version)
+		}
+		upgraded, err := upgrade(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate save file from version %d: %w", version, err)
+		}
+		data = upgraded
+	}
+	return data, nil
+}
+
+// Save writes the save file to path atomically, so a crash midway never leaves
+// a half written save file behind
+func Save(path string, saveFile *SaveFile) error {
+	saveFile.Version = CurrentVersion
+	saveFile.SavedAt = time.Now()
+	data, err := json.Marshal(saveFile)
+	if err != nil {
+		return fmt.Errorf("failed to marshal save file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create save dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp save file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync save file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close save file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace save file: %w", err)
+	}
+	return nil
+}
+
+// Quarantine moves an unreadable save file out of the way, so it's kept around
+// for recovery instead of being overwritten by the next save
+func Quarantine(path string) (string, error) {
+	backupPath := fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102-150405"))
+	if err := os.Rename(path, backupPath); err != nil {
+		return "", fmt.Errorf("failed to move save file aside: %w", err)
+	}
+	return backupPath, nil
+}
@@ -0,0 +1,196 @@
+package savefile
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+	"github.com/maniac-en/pokefetch/internal/inventory"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "pokedex.json")
+	saveFile := New()
+	saveFile.Pokedex["pikachu"] = client.Pokemon{ID: 25, Name: "pikachu", BaseExperience: 112}
+	saveFile.Seed = 42
+
+	if err := Save(path, saveFile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Version != CurrentVersion {
+		t.Errorf("expected version %d, got %d", CurrentVersion, loaded.Version)
+	}
+	if loaded.Seed != 42 {
+		t.Errorf("expected seed 42, got %d", loaded.Seed)
+	}
+	pokemon, ok := loaded.Pokedex["pikachu"]
+	if !ok {
+		t.Fatalf("expected pikachu in the loaded pokedex")
+	}
+	if pokemon.ID != 25 || pokemon.BaseExperience != 112 {
+		t.Errorf("expected pikachu to round trip, got %+v", pokemon)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the save file to be left behind, got %d entries", len(entries))
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     *string
+		expectedErr error
+	}{
+		{
+			name:        "missing file",
+			content:     nil,
+			expectedErr: fs.ErrNotExist,
+		},
+		{
+			name:        "invalid json",
+			content:     stringPtr(`{"version": 1, "pokedex": `),
+			expectedErr: ErrCorrupt,
+		},
+		{
+			name:        "missing version",
+			content:     stringPtr(`{"pokedex": {}}`),
+			expectedErr: ErrCorrupt,
+		},
+		{
+			name:        "newer version",
+			content:     stringPtr(`{"version": 999, "pokedex": {}}`),
+			expectedErr: ErrUnsupportedVersion,
+		},
+		{
+			name:        "older version without migration",
+			content:     stringPtr(`{"version": -1, "pokedex": {}}`),
+			expectedErr: ErrUnsupportedVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pokedex.json")
+			if tt.content != nil {
+				if err := os.WriteFile(path, []byte(*tt.content), 0o644); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			saveFile, err := Load(path)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error matching %v, got %v", tt.expectedErr, err)
+			}
+			if saveFile != nil {
+				t.Errorf("expected nil save file on error, got %+v", saveFile)
+			}
+		})
+	}
+}
+
+func TestLoad_Migration(t *testing.T) {
+	// a made up version 0 which predates the first save file schema, it's
+	// migrated through every real migration after this one
+	migrations[0] = func(data json.RawMessage) (json.RawMessage, error) {
+		var old struct {
+			Caught []string `json:"caught"`
+		}
+		if err := json.Unmarshal(data, &old); err != nil {
+			return nil, err
+		}
+		pokedex := make(map[string]client.Pokemon)
+		for _, name := range old.Caught {
+			pokedex[name] = client.Pokemon{Name: name}
+		}
+		return json.Marshal(map[string]any{"version": 1, "pokedex": pokedex})
+	}
+	defer delete(migrations, 0)
+
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	content := []byte(`{"version": 0, "caught": ["bulbasaur"]}`)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saveFile, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := saveFile.Pokedex["bulbasaur"]; !ok {
+		t.Errorf("expected bulbasaur to be migrated, got %+v", saveFile.Pokedex)
+	}
+}
+
+func TestLoad_MigrationAddsInventory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	content := []byte(`{"version": 1, "pokedex": {"pikachu": {"name": "pikachu"}}}`)
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saveFile, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(saveFile.Inventory, inventory.Starter()) {
+		t.Errorf("expected the starter bag, got %v", saveFile.Inventory)
+	}
+	if _, ok := saveFile.Pokedex["pikachu"]; !ok {
+		t.Errorf("expected pikachu to be kept, got %+v", saveFile.Pokedex)
+	}
+}
+
+func TestSaveAndLoad_EmptyInventory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	saveFile := New()
+	saveFile.Inventory = inventory.New()
+
+	if err := Save(path, saveFile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded.Inventory) != 0 {
+		t.Errorf("expected the used up bag to stay empty, got %v", loaded.Inventory)
+	}
+}
+
+func TestQuarantine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	if err := os.WriteFile(path, []byte("garbage"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	backupPath, err := Quarantine(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected %s to be moved away, got %v", path, err)
+	}
+	if data, err := os.ReadFile(backupPath); err != nil || string(data) != "garbage" {
+		t.Errorf("expected backup to keep the original content, got %q (%v)", data, err)
+	}
+}
+
+// Helper function to create string pointer
+func stringPtr(s string) *string {
+	return &s
+}
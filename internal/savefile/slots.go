@@ -0,0 +1,119 @@
+package savefile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	slotsDirName string = "slots"
+	slotExt      string = ".json"
+)
+
+var (
+	ErrInvalidSlotName = errors.New("slot names may only contain letters, digits, '-' and '_'")
+	ErrSlotNotFound    = errors.New("save slot not found")
+	slotNamePattern    = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$`)
+)
+
+// Slots manages named save files in a single directory, so several trainers
+// can keep their own progress on the same machine
+type Slots struct {
+	dir string
+}
+
+type SlotInfo struct {
+	Name         string
+	SavedAt      time.Time
+	PokemonCount int
+}
+
+func NewSlots(dir string) Slots {
+	return Slots{dir: dir}
+}
+
+// DefaultSlots returns the slots stored under DefaultDir
+func DefaultSlots() (Slots, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return Slots{}, err
+	}
+	return NewSlots(filepath.Join(dir, slotsDirName)), nil
+}
+
+func (s Slots) path(name string) (string, error) {
+	if !slotNamePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSlotName, name)
+	}
+	return filepath.Join(s.dir, name+slotExt), nil
+}
+
+func (s Slots) Save(name string, saveFile *SaveFile) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return Save(path, saveFile)
+}
+
+func (s Slots) Load(name string) (*SaveFile, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	saveFile, err := Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrSlotNotFound, name)
+	}
+	return saveFile, err
+}
+
+func (s Slots) Delete(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrSlotNotFound, name)
+		}
+		return fmt.Errorf("failed to delete save slot: %w", err)
+	}
+	return nil
+}
+
+// List returns the saved slots sorted by name, slots which can't be read are
+// still listed so they can be deleted
+func (s Slots) List() ([]SlotInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read save slots: %w", err)
+	}
+	var slots []SlotInfo
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), slotExt)
+		if entry.IsDir() || !ok || !slotNamePattern.MatchString(name) {
+			continue
+		}
+		info := SlotInfo{Name: name}
+		if saveFile, err := Load(filepath.Join(s.dir, entry.Name())); err == nil {
+			info.SavedAt = saveFile.SavedAt
+			info.PokemonCount = len(saveFile.Pokedex)
+		}
+		slots = append(slots, info)
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Name < slots[j].Name
+	})
+	return slots, nil
+}
@@ -0,0 +1,102 @@
+package savefile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+func TestSlots_SaveLoadDelete(t *testing.T) {
+	slots := NewSlots(filepath.Join(t.TempDir(), "slots"))
+	nextURL := "https://pokeapi.co/api/v2/location-area?offset=40&limit=20"
+	prevURL := "https://pokeapi.co/api/v2/location-area?offset=0&limit=20"
+
+	saveFile := New()
+	saveFile.Pokedex["pikachu"] = client.Pokemon{Name: "pikachu"}
+	saveFile.NextMapAreaURL = &nextURL
+	saveFile.PrevMapAreaURL = &prevURL
+	if err := slots.Save("ash", saveFile); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := slots.Load("ash")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := loaded.Pokedex["pikachu"]; !ok {
+		t.Errorf("expected pikachu in the loaded pokedex")
+	}
+	if loaded.NextMapAreaURL == nil || *loaded.NextMapAreaURL != nextURL {
+		t.Errorf("expected next URL %s, got %v", nextURL, loaded.NextMapAreaURL)
+	}
+	if loaded.PrevMapAreaURL == nil || *loaded.PrevMapAreaURL != prevURL {
+		t.Errorf("expected previous URL %s, got %v", prevURL, loaded.PrevMapAreaURL)
+	}
+
+	if err := slots.Delete("ash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := slots.Load("ash"); !errors.Is(err, ErrSlotNotFound) {
+		t.Errorf("expected ErrSlotNotFound after delete, got %v", err)
+	}
+	if err := slots.Delete("ash"); !errors.Is(err, ErrSlotNotFound) {
+		t.Errorf("expected ErrSlotNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestSlots_InvalidName(t *testing.T) {
+	slots := NewSlots(t.TempDir())
+	for _, name := range []string{"", "../escape", "a/b", ".hidden", "with space"} {
+		if err := slots.Save(name, New()); !errors.Is(err, ErrInvalidSlotName) {
+			t.Errorf("expected ErrInvalidSlotName for %q, got %v", name, err)
+		}
+	}
+}
+
+func TestSlots_List(t *testing.T) {
+	dir := t.TempDir()
+	slots := NewSlots(dir)
+
+	if infos, err := slots.List(); err != nil || len(infos) != 0 {
+		t.Fatalf("expected no slots, got %v (%v)", infos, err)
+	}
+
+	misty := New()
+	misty.Pokedex["staryu"] = client.Pokemon{Name: "staryu"}
+	misty.Pokedex["starmie"] = client.Pokemon{Name: "starmie"}
+	if err := slots.Save("misty", misty); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := slots.Save("brock", New()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	infos, err := slots.List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedNames := []string{"brock", "broken", "misty"}
+	if len(infos) != len(expectedNames) {
+		t.Fatalf("expected %d slots, got %+v", len(expectedNames), infos)
+	}
+	for i, name := range expectedNames {
+		if infos[i].Name != name {
+			t.Errorf("expected slot %d to be %s, got %s", i, name, infos[i].Name)
+		}
+	}
+	if !infos[1].SavedAt.IsZero() {
+		t.Errorf("expected broken slot to have no saved time")
+	}
+	if infos[2].PokemonCount != 2 {
+		t.Errorf("expected misty to have 2 pokemons, got %d", infos[2].PokemonCount)
+	}
+}
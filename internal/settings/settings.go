@@ -0,0 +1,72 @@
+// Package settings resolves the PokeFetch settings from the config file, the
+// environment and the command line flags, in increasing order of precedence
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	appDirName    string = "pokefetch"
+	fileName      string = "config.json"
+	EnvBaseURL    string = "POKEFETCH_BASE_URL"
+	EnvAPIVersion string = "POKEFETCH_API_VERSION"
+)
+
+// Settings are the user configurable knobs, empty fields are left to the
+// defaults of the packages they configure
+type Settings struct {
+	BaseURL    string `json:"base_url"`
+	APIVersion string `json:"api_version"`
+}
+
+// DefaultPath returns the config file location under the user's config dir
+func DefaultPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the user config dir: %w", err)
+	}
+	return filepath.Join(configDir, appDirName, fileName), nil
+}
+
+// LoadFile reads the settings from a JSON config file, a missing file yields
+// empty settings
+func LoadFile(path string) (Settings, error) {
+	var s Settings
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+		return s, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// FromEnv reads the settings from the environment through lookup, which is
+// os.Getenv outside of tests
+func FromEnv(lookup func(string) string) Settings {
+	return Settings{
+		BaseURL:    lookup(EnvBaseURL),
+		APIVersion: lookup(EnvAPIVersion),
+	}
+}
+
+// Override returns s with the non empty fields of other taking precedence
+func (s Settings) Override(other Settings) Settings {
+	if other.BaseURL != "" {
+		s.BaseURL = other.BaseURL
+	}
+	if other.APIVersion != "" {
+		s.APIVersion = other.APIVersion
+	}
+	return s
+}
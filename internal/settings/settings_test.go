@@ -0,0 +1,81 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     *string
+		expected    Settings
+		expectError bool
+	}{
+		{
+			name:     "missing file",
+			content:  nil,
+			expected: Settings{},
+		},
+		{
+			name:     "base URL and API version",
+			content:  stringPtr(`{"base_url": "http://localhost:8000/api", "api_version": "/v2"}`),
+			expected: Settings{BaseURL: "http://localhost:8000/api", APIVersion: "/v2"},
+		},
+		{
+			name:     "partial file",
+			content:  stringPtr(`{"base_url": "http://localhost:8000/api"}`),
+			expected: Settings{BaseURL: "http://localhost:8000/api"},
+		},
+		{
+			name:        "invalid json",
+			content:     stringPtr(`{"base_url": `),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if tt.content != nil {
+				if err := os.WriteFile(path, []byte(*tt.content), 0o644); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			actual, err := LoadFile(path)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	file := Settings{BaseURL: "http://file/api", APIVersion: "/v1"}
+	env := FromEnv(func(key string) string {
+		return map[string]string{EnvBaseURL: "http://env/api"}[key]
+	})
+	flags := Settings{APIVersion: "/v3"}
+
+	actual := file.Override(env).Override(flags)
+	expected := Settings{BaseURL: "http://env/api", APIVersion: "/v3"}
+	if actual != expected {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+}
+
+// Helper function to create string pointer
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,128 @@
+// Package typechart computes how effective the pokemon types are against each
+// other, out of the damage relations PokeAPI gives for every type
+package typechart
+
+import (
+	"slices"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+// Standard lists the 18 types of the main series games in their usual order
+var Standard = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// Chart is a matrix of damage multipliers, it only knows about the types
+// added to it and is not safe for concurrent use
+type Chart struct {
+	// multipliers maps the attacking type to the defending type to the damage
+	// multiplier, the pairs missing are a neutral 1
+	multipliers map[string]map[string]float64
+	// known are the types added to the chart, their defending multipliers are
+	// complete
+	known map[string]bool
+}
+
+func New() *Chart {
+	return &Chart{
+		multipliers: make(map[string]map[string]float64),
+		known:       make(map[string]bool),
+	}
+}
+
+// Add records the damage relations of a type, both as the attacking and as
+// the defending one
+func (c *Chart) Add(t client.Type) {
+	relations := t.DamageRelations
+	for _, to := range relations.NoDamageTo {
+		c.set(t.Name, to.Name, 0)
+	}
+	for _, to := range relations.HalfDamageTo {
+		c.set(t.Name, to.Name, 0.5)
+	}
+	for _, to := range relations.DoubleDamageTo {
+		c.set(t.Name, to.Name, 2)
+	}
+	for _, from := range relations.NoDamageFrom {
+		c.set(from.Name, t.Name, 0)
+	}
+	for _, from := range relations.HalfDamageFrom {
+		c.set(from.Name, t.Name, 0.5)
+	}
+	for _, from := range relations.DoubleDamageFrom {
+		c.set(from.Name, t.Name, 2)
+	}
+	c.known[t.Name] = true
+}
+
+func (c *Chart) set(attacking, defending string, multiplier float64) {
+	if c.multipliers[attacking] == nil {
+		c.multipliers[attacking] = make(map[string]float64)
+	}
+	c.multipliers[attacking][defending] = multiplier
+}
+
+// Has reports whether the type was added to the chart
+func (c *Chart) Has(typeName string) bool {
+	return c.known[typeName]
+}
+
+// Multiplier returns the damage multiplier of an attacking type against a
+// defending one, 1 when they're not related
+func (c *Chart) Multiplier(attacking, defending string) float64 {
+	if multiplier, ok := c.multipliers[attacking][defending]; ok {
+		return multiplier
+	}
+	return 1
+}
+
+// Defense is how a pokemon of the given types fares against every attacking
+// type, the neutral ones left out. Each list is sorted by type name.
+type Defense struct {
+	// Weaknesses take 2x or 4x damage
+	Weaknesses []Matchup
+	// Resistances take 0.5x or 0.25x damage
+	Resistances []Matchup
+	// Immunities take no damage
+	Immunities []Matchup
+}
+
+type Matchup struct {
+	Type       string
+	Multiplier float64
+}
+
+// Defending combines the multipliers of the defending types, e.g. a
+// water/ground pokemon is 4x weak to grass and immune to electric
+func (c *Chart) Defending(types ...string) Defense {
+	var defense Defense
+	for _, attacking := range c.attackingTypes() {
+		multiplier := 1.0
+		for _, defending := range types {
+			multiplier *= c.Multiplier(attacking, defending)
+		}
+		matchup := Matchup{Type: attacking, Multiplier: multiplier}
+		switch {
+		case multiplier == 0:
+			defense.Immunities = append(defense.Immunities, matchup)
+		case multiplier < 1:
+			defense.Resistances = append(defense.Resistances, matchup)
+		case multiplier > 1:
+			defense.Weaknesses = append(defense.Weaknesses, matchup)
+		}
+	}
+	return defense
+}
+
+// attackingTypes returns every type the chart has a multiplier for, sorted
+func (c *Chart) attackingTypes() []string {
+	types := make([]string, 0, len(c.multipliers))
+	for attacking := range c.multipliers {
+		types = append(types, attacking)
+	}
+	slices.Sort(types)
+	return types
+}
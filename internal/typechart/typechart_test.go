@@ -0,0 +1,89 @@
+package typechart
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/maniac-en/pokefetch/internal/client"
+)
+
+func named(names ...string) []client.NamedAPIResource {
+	resources := make([]client.NamedAPIResource, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, client.NamedAPIResource{Name: name})
+	}
+	return resources
+}
+
+func newTestChart() *Chart {
+	chart := New()
+	chart.Add(client.Type{
+		Name: "water",
+		DamageRelations: client.TypeRelations{
+			HalfDamageTo:     named("water", "grass", "dragon"),
+			DoubleDamageTo:   named("fire", "ground", "rock"),
+			HalfDamageFrom:   named("fire", "water", "ice", "steel"),
+			DoubleDamageFrom: named("electric", "grass"),
+		},
+	})
+	chart.Add(client.Type{
+		Name: "ground",
+		DamageRelations: client.TypeRelations{
+			NoDamageTo:       named("flying"),
+			HalfDamageTo:     named("grass", "bug"),
+			DoubleDamageTo:   named("fire", "electric", "poison", "rock", "steel"),
+			NoDamageFrom:     named("electric"),
+			HalfDamageFrom:   named("poison", "rock"),
+			DoubleDamageFrom: named("water", "grass", "ice"),
+		},
+	})
+	return chart
+}
+
+func TestChart_Multiplier(t *testing.T) {
+	chart := newTestChart()
+	tests := []struct {
+		attacking, defending string
+		expected             float64
+	}{
+		{"water", "fire", 2},
+		{"water", "grass", 0.5},
+		{"ground", "flying", 0},
+		{"electric", "ground", 0},
+		{"grass", "water", 2},
+		{"normal", "water", 1},
+	}
+
+	for _, tt := range tests {
+		if got := chart.Multiplier(tt.attacking, tt.defending); got != tt.expected {
+			t.Errorf("%s against %s: expected %v, got %v", tt.attacking, tt.defending, tt.expected, got)
+		}
+	}
+	if !chart.Has("water") || chart.Has("fire") {
+		t.Errorf("expected only the added types to be known")
+	}
+}
+
+func TestChart_DefendingDualType(t *testing.T) {
+	chart := newTestChart()
+
+	defense := chart.Defending("water", "ground")
+
+	expectedWeaknesses := []Matchup{{Type: "grass", Multiplier: 4}}
+	if !reflect.DeepEqual(defense.Weaknesses, expectedWeaknesses) {
+		t.Errorf("expected weaknesses %v, got %v", expectedWeaknesses, defense.Weaknesses)
+	}
+	expectedResistances := []Matchup{
+		{Type: "fire", Multiplier: 0.5},
+		{Type: "poison", Multiplier: 0.5},
+		{Type: "rock", Multiplier: 0.5},
+		{Type: "steel", Multiplier: 0.5},
+	}
+	if !reflect.DeepEqual(defense.Resistances, expectedResistances) {
+		t.Errorf("expected resistances %v, got %v", expectedResistances, defense.Resistances)
+	}
+	expectedImmunities := []Matchup{{Type: "electric", Multiplier: 0}}
+	if !reflect.DeepEqual(defense.Immunities, expectedImmunities) {
+		t.Errorf("expected immunities %v, got %v", expectedImmunities, defense.Immunities)
+	}
+}